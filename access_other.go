@@ -0,0 +1,26 @@
+//go:build !(linux || darwin || dragonfly || freebsd || netbsd || openbsd)
+
+package argparse
+
+import "os"
+
+// Access modes checked by access.
+const (
+	readAccess  = 0x4
+	writeAccess = 0x2
+)
+
+// access returns an error if the current user is not permitted to access the
+// provided path using the mode. Lacking access(2), the file is opened and
+// immediately closed, without being modified.
+func access(path string, mode uint32) error {
+	flag := os.O_RDONLY
+	if mode&writeAccess != 0 {
+		flag = os.O_WRONLY | os.O_APPEND
+	}
+	file, err := os.OpenFile(path, flag, 0)
+	if err != nil {
+		return err
+	}
+	return file.Close()
+}
@@ -0,0 +1,17 @@
+//go:build linux || darwin || dragonfly || freebsd || netbsd || openbsd
+
+package argparse
+
+import "syscall"
+
+// Access modes checked by access, matching the kernel's R_OK and W_OK.
+const (
+	readAccess  = 0x4
+	writeAccess = 0x2
+)
+
+// access returns an error if the current user is not permitted to access the
+// provided path using the mode, without opening it.
+func access(path string, mode uint32) error {
+	return syscall.Access(path, mode)
+}
@@ -11,6 +11,18 @@ import (
 // a option, and an array of argument strings.
 type Action func(*Parser, *Option, ...string) ([]string, error)
 
+// validateArg returns an error if the provided argument is not a valid choice,
+// cannot be converted to the option's type, or does not satisfy the option's
+// validators.
+func validateArg(f Option, arg string) error {
+	if err := ValidateChoice(f, arg); err != nil {
+		return err
+	} else if err := ValidateType(f, arg); err != nil {
+		return err
+	}
+	return ValidateValue(f, arg)
+}
+
 // Store will attempt to store the appropriate number of arguments for the option,
 // (if any), into the parser. Remaining arguments & any errors are returned.
 func Store(p *Parser, f *Option, args ...string) ([]string, error) {
@@ -19,9 +31,7 @@ func Store(p *Parser, f *Option, args ...string) ([]string, error) {
 		panic(fmt.Sprintf("option '%s' must expect at least one argument", f.DisplayName()))
 	} else if f.ArgNum == "?" {
 		if len(args) > 0 {
-			if err := ValidateChoice(*f, args[0]); err != nil {
-				return args, err
-			} else if err := ValidateType(*f, args[0]); err != nil {
+			if err := validateArg(*f, args[0]); err != nil {
 				return args, err
 			}
 			p.Namespace.Set(f.DestName, args[0])
@@ -29,13 +39,11 @@ func Store(p *Parser, f *Option, args ...string) ([]string, error) {
 		}
 	} else if strings.ContainsAny(f.ArgNum, "*+rR") {
 		if f.ArgNum == "+" && len(args) == 0 {
-			return args, TooFewArgsErr{*f}
+			return args, TooFewArgsErr{Opt: *f}
 		}
 		var values []string
 		for len(args) > 0 {
-			if err := ValidateChoice(*f, args[0]); err != nil {
-				return args, err
-			} else if err := ValidateType(*f, args[0]); err != nil {
+			if err := validateArg(*f, args[0]); err != nil {
 				return args, err
 			}
 			values = append(values, args[0])
@@ -48,7 +56,7 @@ func Store(p *Parser, f *Option, args ...string) ([]string, error) {
 		num, _ := strconv.Atoi(f.ArgNum)
 		if len(args) < num {
 			if f.IsRequired {
-				return args, TooFewArgsErr{*f}
+				return args, TooFewArgsErr{Opt: *f}
 			}
 			return args, nil
 		}
@@ -56,9 +64,7 @@ func Store(p *Parser, f *Option, args ...string) ([]string, error) {
 		if num > 1 {
 			var values []string
 			for _, v := range args[0:num] {
-				if err := ValidateChoice(*f, v); err != nil {
-					return args, err
-				} else if err := ValidateType(*f, v); err != nil {
+				if err := validateArg(*f, v); err != nil {
 					return args, err
 				}
 				values = append(values, v)
@@ -70,9 +76,7 @@ func Store(p *Parser, f *Option, args ...string) ([]string, error) {
 				args = args[num:]
 			}
 		} else {
-			if err := ValidateChoice(*f, args[0]); err != nil {
-				return args, err
-			} else if err := ValidateType(*f, args[0]); err != nil {
+			if err := validateArg(*f, args[0]); err != nil {
 				return args, err
 			}
 			p.Namespace.Set(f.DestName, args[0])
@@ -138,14 +142,12 @@ func Append(p *Parser, f *Option, args ...string) ([]string, error) {
 	if regexp.MustCompile(`^[1-9]+$`).MatchString(f.ArgNum) {
 		num, _ := strconv.Atoi(f.ArgNum)
 		if len(args) < num {
-			return args, TooFewArgsErr{*f}
+			return args, TooFewArgsErr{Opt: *f}
 		}
 
 		count := 0
 		for count < num {
-			if err := ValidateChoice(*f, args[0]); err != nil {
-				return args, err
-			} else if err := ValidateType(*f, args[0]); err != nil {
+			if err := validateArg(*f, args[0]); err != nil {
 				return args, err
 			}
 			appendValue(p, f, args[0])
@@ -169,9 +171,7 @@ func Append(p *Parser, f *Option, args ...string) ([]string, error) {
 		return args, nil
 	} else if f.ArgNum == "?" {
 		if len(args) > 0 {
-			if err := ValidateChoice(*f, args[0]); err != nil {
-				return args, err
-			} else if err := ValidateType(*f, args[0]); err != nil {
+			if err := validateArg(*f, args[0]); err != nil {
 				return args, err
 			}
 			appendValue(p, f, args[0])
@@ -192,13 +192,11 @@ func Append(p *Parser, f *Option, args ...string) ([]string, error) {
 		}
 	} else if strings.ContainsAny(f.ArgNum, "*+rR") {
 		if f.ArgNum == "+" && len(args) == 0 {
-			return args, MissingOneOrMoreArgsErr{*f}
+			return args, MissingOneOrMoreArgsErr{Opt: *f}
 		}
 
 		for len(args) > 0 {
-			if err := ValidateChoice(*f, args[0]); err != nil {
-				return args, err
-			} else if err := ValidateType(*f, args[0]); err != nil {
+			if err := validateArg(*f, args[0]); err != nil {
 				return args, err
 			}
 			appendValue(p, f, args[0])
@@ -238,6 +236,35 @@ func ShowHelp(p *Parser, f *Option, args ...string) ([]string, error) {
 	return args, ShowHelpErr{}
 }
 
+// ShowCommandHelp outputs the help text of the command named by the provided
+// arguments, beginning from the parent of the provided parser, which is used by
+// the command added by Parser.AddHelpCommand. The help text's usage shows the
+// full command path, such as `prog cluster scale`. It returns a MissingParserErr
+// if a command does not exist, or otherwise a ShowHelpErr error instance, used
+// to prevent further parsing.
+func ShowCommandHelp(p *Parser, f *Option, args ...string) ([]string, error) {
+	target := p
+	if p.parent != nil {
+		target = p.parent
+	}
+
+	for _, name := range args {
+		subP, suggestions, ok := target.matchParser(name)
+		if !ok {
+			if len(suggestions) == 0 {
+				suggestions = target.suggestCommands(name)
+			}
+			return nil, MissingParserErr{Parsers: target.Parsers, Name: name, Suggestions: suggestions}
+		}
+		target = subP.Parser
+	}
+
+	help := *target
+	help.ProgramName = join(" ", target.commandPath()...)
+	help.ShowHelp()
+	return nil, ShowHelpErr{}
+}
+
 // ShowVersion calls the parser's ShowVersion function to output parser/program
 // version information. Provided arguments remain unchanged. It returns a ShowVersionErr
 // instance, used to prevent further parsing.
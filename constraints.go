@@ -0,0 +1,201 @@
+package argparse
+
+import (
+	"strings"
+)
+
+// Condition is satisfied when the namespace value of the destination is equal
+// to, or contains, the value.
+type Condition struct {
+	Dest  string
+	Value string
+}
+
+// satisfied returns true if the condition is satisfied by the namespace.
+func (c Condition) satisfied(ns *Namespace) bool {
+	if ns == nil {
+		return false
+	}
+
+	switch value := ns.Get(c.Dest).(type) {
+	case string:
+		return value == c.Value
+	case []string:
+		for _, v := range value {
+			if v == c.Value {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Requires sets the public names of options which must also be provided when
+// the option is provided.
+func (f *Option) Requires(names ...string) *Option {
+	f.RequiredNames = append(f.RequiredNames, names...)
+	return f
+}
+
+// ConflictsWith sets the public names of options which cannot be provided along
+// with the option.
+func (f *Option) ConflictsWith(names ...string) *Option {
+	f.ConflictNames = append(f.ConflictNames, names...)
+	return f
+}
+
+// RequiredIf requires the option to be provided when the namespace value of
+// the destination is equal to, or contains, the provided value once parsed.
+func (f *Option) RequiredIf(dest, value string) *Option {
+	f.RequiredConditions = append(f.RequiredConditions, Condition{Dest: dest, Value: value})
+	return f
+}
+
+// RequireOneOf requires at least one of the provided options to be provided
+// when parsing.
+func (p *Parser) RequireOneOf(opts ...*Option) *Parser {
+	p.OneOfGroups = append(p.OneOfGroups, opts)
+	return p
+}
+
+// constraintName returns the name used to refer to the option within
+// constraints, preferring its longest public name.
+func constraintName(opt *Option) string {
+	if opt.IsPositional {
+		return opt.DisplayName()
+	}
+
+	longest := ""
+	for _, name := range opt.PublicNames {
+		if len(name) > len(longest) {
+			longest = name
+		}
+	}
+	return optionName(longest)
+}
+
+// constraintNames returns the names used to refer to the provided options
+// within constraints, separated by commas.
+func constraintNames(opts []*Option) string {
+	var names []string
+	for _, opt := range opts {
+		names = append(names, constraintName(opt))
+	}
+	return strings.Join(names, ", ")
+}
+
+// constraintText returns a description of the option's constraints within the
+// parser for use within help text, or an empty string if it has none.
+func (p *Parser) constraintText(c Catalog, opt *Option) string {
+	var texts []string
+
+	if len(opt.RequiredNames) > 0 {
+		var names []string
+		for _, name := range opt.RequiredNames {
+			names = append(names, optionName(name))
+		}
+		texts = append(texts, sprintMessage(c, MsgHelpRequires, len(names), strings.Join(names, ", ")))
+	}
+	if len(opt.ConflictNames) > 0 {
+		var names []string
+		for _, name := range opt.ConflictNames {
+			names = append(names, optionName(name))
+		}
+		texts = append(texts, sprintMessage(c, MsgHelpConflicts, len(names), strings.Join(names, ", ")))
+	}
+	for _, cond := range opt.RequiredConditions {
+		texts = append(texts, sprintMessage(c, MsgHelpRequiredIf, 1, cond.Dest, cond.Value))
+	}
+	for _, group := range p.OneOfGroups {
+		for _, member := range group {
+			if member == opt {
+				texts = append(texts, sprintMessage(c, MsgHelpOneOf, len(group), constraintNames(group)))
+				break
+			}
+		}
+	}
+
+	if len(texts) == 0 {
+		return ""
+	}
+	return join("", "(", strings.Join(texts, "; "), ")")
+}
+
+// checkConstraints returns an error for each constraint of the options of the
+// parser and its parents which is not satisfied. The provided options, of the
+// parser and its parents, are mapped to the index of the argument they were
+// provided by. Conditions are evaluated using the parser's namespace, which
+// contains the values of its parents.
+func (p *Parser) checkConstraints(provided map[*Option]int, argv []string) []error {
+	var errs []error
+	conflicts := make(map[[2]*Option]bool)
+
+	for owner := p; owner != nil; owner = owner.parent {
+		// find returns the option with the provided name, preferring the
+		// options available to the parser over those of the option's owner.
+		find := func(name string) *Option {
+			if option := p.findOption(name); option != nil {
+				return option
+			}
+			return owner.findOption(name)
+		}
+
+		for _, option := range owner.Options {
+			index, ok := provided[option]
+			if !ok {
+				for _, cond := range option.RequiredConditions {
+					if cond.satisfied(p.Namespace) {
+						errs = append(errs, RequiredIfErr{Opt: *option, Dest: cond.Dest, Value: cond.Value, Argv: argv})
+						break
+					}
+				}
+				continue
+			}
+
+			for _, name := range option.RequiredNames {
+				if required := find(name); required == nil || !hasKey(provided, required) {
+					errs = append(errs, DependencyErr{Opt: *option, Required: optionName(name), ArgIndex: index, Argv: argv})
+				}
+			}
+
+			for _, name := range option.ConflictNames {
+				conflict := find(name)
+				conflictIndex, ok := provided[conflict]
+				if conflict == nil || !ok || conflicts[[2]*Option{option, conflict}] {
+					continue
+				}
+				conflicts[[2]*Option{option, conflict}] = true
+				conflicts[[2]*Option{conflict, option}] = true
+
+				// The caret is placed beneath whichever option was provided last.
+				if conflictIndex > index {
+					errs = append(errs, ConflictErr{Opt: *conflict, Conflict: constraintName(option), ArgIndex: conflictIndex, Argv: argv})
+				} else {
+					errs = append(errs, ConflictErr{Opt: *option, Conflict: optionName(name), ArgIndex: index, Argv: argv})
+				}
+			}
+		}
+
+		for _, group := range owner.OneOfGroups {
+			found := false
+			for _, option := range group {
+				found = found || hasKey(provided, option)
+			}
+			if !found && len(group) > 0 {
+				var opts []Option
+				for _, option := range group {
+					opts = append(opts, *option)
+				}
+				errs = append(errs, MissingOneOfErr{Opts: opts, Argv: argv})
+			}
+		}
+	}
+
+	return errs
+}
+
+// hasKey returns true if the option is within the provided map.
+func hasKey(provided map[*Option]int, opt *Option) bool {
+	_, ok := provided[opt]
+	return ok
+}
@@ -0,0 +1,146 @@
+package argparse
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// TestParserConstraints tests to ensure unsatisfied constraints between options
+// result in their dedicated errors.
+func TestParserConstraints(t *testing.T) {
+	var err error
+	callback := func(p *Parser, ns *Namespace, args []string, e error) {
+		err = e
+	}
+
+	user := NewOption("u user", "user", "User name").Nargs("1").Action(Store)
+	token := NewOption("t token", "token", "Access token").Nargs("1").Action(Store).ConflictsWith("password")
+	password := NewOption("p password", "password", "Password").Nargs("1").Action(Store).Requires("user")
+	region := NewOption("region", "region", "Region").Nargs("1").Action(Store).Default("us")
+	bucket := NewOption("bucket", "bucket", "Bucket").Nargs("1").Action(Store).RequiredIf("region", "eu")
+
+	p := NewParser("program", callback)
+	p.AddOptions(user, token, password, region, bucket)
+	p.RequireOneOf(token, password)
+
+	tests := []struct {
+		args     []string
+		sentinel error
+		message  string
+		index    int
+	}{
+		{[]string{"--token", "abc"}, nil, "", -1},
+		{[]string{"--password", "secret", "-u", "luke"}, nil, "", -1},
+		{[]string{"--password", "secret"}, ErrMissingDependency, "--password: requires --user", 0},
+		{[]string{"-p", "secret", "-u", "luke", "--token", "abc"}, ErrConflict, "--token: not allowed with --password", 4},
+		{[]string{"--token", "abc", "--region", "eu"}, ErrMissingOption, "option \"--bucket\" required when region is \"eu\"", -1},
+		{[]string{"-u", "luke"}, ErrMissingOption, "one of --token, --password required", -1},
+	}
+
+	for _, test := range tests {
+		err = nil
+		p.Parse(test.args...)
+
+		if test.sentinel == nil {
+			if err != nil {
+				t.Errorf("%v: An unexpected error occurred: %v", test.args, err)
+			}
+			continue
+		}
+		if !errors.Is(err, test.sentinel) {
+			t.Errorf("%v: Expected the error %v, but received: %v", test.args, test.sentinel, err)
+			continue
+		}
+		if err.Error() != test.message {
+			t.Errorf("%v: Expected the message \"%s\", but received \"%s\"", test.args, test.message, err.Error())
+		}
+		if index := err.(ParseError).Index(); index != test.index {
+			t.Errorf("%v: Expected the index %d, but received %d", test.args, test.index, index)
+		}
+	}
+}
+
+// TestParserConstraints_Commands tests to ensure constraints on persistent
+// options are checked once, against the options provided to every parser within
+// the chain of commands.
+func TestParserConstraints_Commands(t *testing.T) {
+	var err error
+	callback := func(p *Parser, ns *Namespace, args []string, e error) {
+		err = e
+	}
+
+	user := NewOption("u user", "user", "User name").Nargs("1").Action(Store).Persistent()
+	token := NewOption("t token", "token", "Access token").Nargs("1").Action(Store).Persistent().ConflictsWith("password")
+	password := NewOption("p password", "password", "Password").Nargs("1").Action(Store).Persistent().Requires("user")
+	region := NewOption("region", "region", "Region").Nargs("1").Action(Store).Persistent().Default("us")
+	bucket := NewOption("bucket", "bucket", "Bucket").Nargs("1").Action(Store).Persistent().RequiredIf("region", "eu")
+
+	p := NewParser("program", callback)
+	p.AddOptions(user, token, password, region, bucket)
+	p.RequireOneOf(token, password)
+	p.AddParser("sub", NewParser("sub", callback))
+
+	tests := []struct {
+		args     []string
+		sentinel error
+		message  string
+		index    int
+	}{
+		{[]string{"--region", "eu", "--bucket", "b", "sub", "--token", "x"}, nil, "", -1},
+		{[]string{"--region", "eu", "sub", "--bucket", "b", "--token", "x"}, nil, "", -1},
+		{[]string{"-u", "luke", "sub", "--password", "x"}, nil, "", -1},
+		{[]string{"sub", "--token", "x"}, nil, "", -1},
+		{[]string{"--token", "x", "sub", "--password", "y", "-u", "luke"}, ErrConflict, "--password: not allowed with --token", 3},
+		{[]string{"--password", "x", "sub"}, ErrMissingDependency, "--password: requires --user", 0},
+		{[]string{"--region", "eu", "sub", "--token", "x"}, ErrMissingOption, "option \"--bucket\" required when region is \"eu\"", -1},
+		{[]string{"-u", "luke", "sub"}, ErrMissingOption, "one of --token, --password required", -1},
+	}
+
+	for _, test := range tests {
+		err = nil
+		p.Parse(test.args...)
+
+		if test.sentinel == nil {
+			if err != nil {
+				t.Errorf("%v: An unexpected error occurred: %v", test.args, err)
+			}
+			continue
+		}
+		if !errors.Is(err, test.sentinel) {
+			t.Errorf("%v: Expected the error %v, but received: %v", test.args, test.sentinel, err)
+			continue
+		}
+		if err.Error() != test.message {
+			t.Errorf("%v: Expected the message \"%s\", but received \"%s\"", test.args, test.message, err.Error())
+		}
+		if index := err.(ParseError).Index(); index != test.index {
+			t.Errorf("%v: Expected the index %d, but received %d", test.args, test.index, index)
+		}
+	}
+}
+
+// TestParserConstraints_Help tests to ensure constraints are described within
+// each option's help text.
+func TestParserConstraints_Help(t *testing.T) {
+	token := NewOption("t token", "token", "Access token").Nargs("1").Action(Store).ConflictsWith("password")
+	password := NewOption("p password", "password", "Password").Nargs("1").Action(Store).Requires("user")
+
+	p := NewParser("program", nil).Prog("prog").Width(100)
+	p.AddOption(NewOption("u user", "user", "User name").Nargs("1").Action(Store))
+	p.AddOptions(token, password)
+	p.AddOption(NewOption("bucket", "bucket", "Bucket").Nargs("1").Action(Store).RequiredIf("region", "eu"))
+	p.RequireOneOf(token, password)
+
+	help := p.GetHelp()
+	expected := []string{
+		"Access token (not allowed with --password; one of --token, --password required)",
+		"Password (requires --user; one of --token, --password required)",
+		"Bucket (required when region is \"eu\")",
+	}
+	for _, text := range expected {
+		if !strings.Contains(help, text) {
+			t.Errorf("Expected the help text to contain \"%s\":\n%s", text, help)
+		}
+	}
+}
@@ -0,0 +1,240 @@
+package argparse
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// docCommand represents a single parser within a command tree, along with the
+// full command path used to reach it.
+type docCommand struct {
+	Parser *Parser
+	Path   []string
+}
+
+// Anchor returns a URL-safe anchor identifier for the command.
+func (c docCommand) Anchor() string {
+	var buff bytes.Buffer
+	for _, r := range strings.ToLower(join("-", c.Path...)) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '-' || r == '_' {
+			buff.WriteRune(r)
+		} else {
+			buff.WriteRune('-')
+		}
+	}
+	return buff.String()
+}
+
+// Name returns the full, space-delimited command path.
+func (c docCommand) Name() string {
+	return join(" ", c.Path...)
+}
+
+// Usage returns a single-line usage string for the command.
+func (c docCommand) Usage() string {
+	usage := []string{c.Name()}
+	var positional []string
+
+	for _, opt := range visibleOptions(c.Parser.Options) {
+		if opt.IsPositional {
+			positional = append(positional, opt.GetUsage())
+		} else {
+			usage = append(usage, opt.GetUsage())
+		}
+	}
+
+	if len(visibleParsers(c.Parser.Parsers)) > 0 {
+		if c.Parser.commandsOptional() {
+			usage = append(usage, join("", "[", commandList(c.Parser.Parsers), "]"))
+		} else {
+			usage = append(usage, commandList(c.Parser.Parsers))
+		}
+	}
+
+	usage = append(usage, positional...)
+	return join(" ", usage...)
+}
+
+// docCommands walks the command tree starting at the provided parser, returning
+// every parser in depth-first order.
+func docCommands(p *Parser, path []string) []docCommand {
+	commands := []docCommand{{Parser: p, Path: path}}
+
+	for _, subP := range visibleParsers(p.Parsers) {
+		subPath := append(append([]string{}, path...), subP.Name)
+		commands = append(commands, docCommands(subP.Parser, subPath)...)
+	}
+
+	return commands
+}
+
+// docType returns the display name of the option's expected type.
+func docType(opt *Option) string {
+	if opt.ExpectedType == reflect.Invalid {
+		return reflect.String.String()
+	}
+	return opt.ExpectedType.String()
+}
+
+// docDefault returns the option's default value, or an empty string if the
+// default is bound to an environmental variable.
+func docDefault(opt *Option) string {
+	if isEnvVarFormat(opt.DefaultVal) {
+		return ""
+	}
+	return opt.DefaultVal
+}
+
+// docEnv returns the name of the environmental variable the option's default
+// value is bound to, if any.
+func docEnv(opt *Option) string {
+	if isEnvVarFormat(opt.DefaultVal) {
+		return opt.DefaultVal[1:]
+	}
+	return ""
+}
+
+// markdownCell escapes text for use within a markdown table cell.
+func markdownCell(text string) string {
+	text = strings.Replace(text, "|", "\\|", -1)
+	return strings.Replace(text, "\n", " ", -1)
+}
+
+// markdownCode wraps non-empty text in a markdown code span.
+func markdownCode(text string) string {
+	if len(text) == 0 {
+		return ""
+	}
+	return join("", "`", markdownCell(text), "`")
+}
+
+// WriteMarkdown writes reference documentation for the parser, and every
+// sub-parser beneath it, to the provided writer as markdown. Each command
+// is given its own anchor so that commands can be linked to directly.
+func (p *Parser) WriteMarkdown(w io.Writer) error {
+	var buff bytes.Buffer
+
+	for _, cmd := range docCommands(p, []string{p.ProgramName}) {
+		level := len(cmd.Path)
+		if level > 6 {
+			level = 6
+		}
+
+		fmt.Fprintf(&buff, "<a name=\"%s\"></a>\n", cmd.Anchor())
+		fmt.Fprintf(&buff, "%s %s\n\n", strings.Repeat("#", level), cmd.Name())
+
+		if len(cmd.Parser.UsageText) > 0 {
+			fmt.Fprintf(&buff, "%s\n\n", cmd.Parser.UsageText)
+		}
+
+		fmt.Fprintf(&buff, "```\nusage: %s\n```\n\n", cmd.Usage())
+
+		if len(visibleOptions(cmd.Parser.Options)) > 0 {
+			buff.WriteString("| Option | Type | Default | Choices | Env | Description |\n")
+			buff.WriteString("|---|---|---|---|---|---|\n")
+
+			for _, opt := range visibleOptions(cmd.Parser.Options) {
+				fmt.Fprintf(
+					&buff,
+					"| %s | %s | %s | %s | %s | %s |\n",
+					markdownCode(opt.DisplayName()),
+					docType(opt),
+					markdownCode(docDefault(opt)),
+					markdownCell(strings.Join(opt.ValidChoices, ", ")),
+					markdownCode(docEnv(opt)),
+					markdownCell(opt.HelpText),
+				)
+			}
+			buff.WriteString("\n")
+		}
+
+		if len(visibleParsers(cmd.Parser.Parsers)) > 0 {
+			buff.WriteString("Commands:\n\n")
+			for _, subP := range visibleParsers(cmd.Parser.Parsers) {
+				subCmd := docCommand{Path: append(append([]string{}, cmd.Path...), subP.Name)}
+				fmt.Fprintf(&buff, "- [%s](#%s)", subP.Name, subCmd.Anchor())
+				if len(subP.HelpText) > 0 {
+					fmt.Fprintf(&buff, ": %s", subP.HelpText)
+				}
+				buff.WriteString("\n")
+			}
+			buff.WriteString("\n")
+		}
+
+		if len(cmd.Parser.EpilogText) > 0 {
+			fmt.Fprintf(&buff, "%s\n\n", cmd.Parser.EpilogText)
+		}
+	}
+
+	_, err := w.Write(buff.Bytes())
+	return err
+}
+
+// WriteHTML writes reference documentation for the parser, and every
+// sub-parser beneath it, to the provided writer as an HTML fragment. Each
+// command is wrapped in a section with its own anchor.
+func (p *Parser) WriteHTML(w io.Writer) error {
+	var buff bytes.Buffer
+	esc := html.EscapeString
+
+	for _, cmd := range docCommands(p, []string{p.ProgramName}) {
+		level := len(cmd.Path)
+		if level > 6 {
+			level = 6
+		}
+
+		fmt.Fprintf(&buff, "<section id=\"%s\">\n", cmd.Anchor())
+		fmt.Fprintf(&buff, "<h%d>%s</h%d>\n", level, esc(cmd.Name()), level)
+
+		if len(cmd.Parser.UsageText) > 0 {
+			fmt.Fprintf(&buff, "<p>%s</p>\n", esc(cmd.Parser.UsageText))
+		}
+
+		fmt.Fprintf(&buff, "<pre>usage: %s</pre>\n", esc(cmd.Usage()))
+
+		if len(visibleOptions(cmd.Parser.Options)) > 0 {
+			buff.WriteString("<table>\n")
+			buff.WriteString("<tr><th>Option</th><th>Type</th><th>Default</th><th>Choices</th><th>Env</th><th>Description</th></tr>\n")
+
+			for _, opt := range visibleOptions(cmd.Parser.Options) {
+				fmt.Fprintf(
+					&buff,
+					"<tr><td><code>%s</code></td><td>%s</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td></tr>\n",
+					esc(opt.DisplayName()),
+					esc(docType(opt)),
+					esc(docDefault(opt)),
+					esc(strings.Join(opt.ValidChoices, ", ")),
+					esc(docEnv(opt)),
+					esc(opt.HelpText),
+				)
+			}
+			buff.WriteString("</table>\n")
+		}
+
+		if len(visibleParsers(cmd.Parser.Parsers)) > 0 {
+			buff.WriteString("<ul>\n")
+			for _, subP := range visibleParsers(cmd.Parser.Parsers) {
+				subCmd := docCommand{Path: append(append([]string{}, cmd.Path...), subP.Name)}
+				fmt.Fprintf(&buff, "<li><a href=\"#%s\">%s</a>", subCmd.Anchor(), esc(subP.Name))
+				if len(subP.HelpText) > 0 {
+					fmt.Fprintf(&buff, ": %s", esc(subP.HelpText))
+				}
+				buff.WriteString("</li>\n")
+			}
+			buff.WriteString("</ul>\n")
+		}
+
+		if len(cmd.Parser.EpilogText) > 0 {
+			fmt.Fprintf(&buff, "<p>%s</p>\n", esc(cmd.Parser.EpilogText))
+		}
+
+		buff.WriteString("</section>\n")
+	}
+
+	_, err := w.Write(buff.Bytes())
+	return err
+}
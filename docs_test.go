@@ -0,0 +1,75 @@
+package argparse
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestParserWriteMarkdown tests to ensure the markdown documentation contains
+// every command within the tree, along with its options and their attributes.
+func TestParserWriteMarkdown(t *testing.T) {
+	scale := NewParser("Scale a cluster", emptyNamespace())
+	scale.AddOption(NewOption("replicas", "replicas", "Number of replicas").Nargs("1").Action(Store).Type(reflect.Int).Default("3"))
+
+	cluster := NewParser("Manage clusters", emptyNamespace())
+	cluster.AddParser("scale", scale)
+
+	p := NewParser("Deploy things", emptyNamespace()).Prog("prog")
+	p.AddHelp()
+	p.AddOption(NewOption("region", "region", "Target region").Nargs("1").Action(Store).Choices("us", "eu").Default("$PROG_REGION"))
+	p.AddParser("cluster", cluster, "Manage clusters")
+
+	var buff bytes.Buffer
+	if err := p.WriteMarkdown(&buff); err != nil {
+		t.Fatalf("An unexpected error occurred: %s", err.Error())
+	}
+	doc := buff.String()
+
+	expected := []string{
+		"<a name=\"prog\"></a>",
+		"# prog",
+		"<a name=\"prog-cluster-scale\"></a>",
+		"### prog cluster scale",
+		"[cluster](#prog-cluster): Manage clusters",
+		"| `--replicas` | int | `3` |",
+		"| `--region` | string |  | us, eu | `PROG_REGION` | Target region |",
+		"usage: prog [-h] [--region {US,EU}] {cluster}",
+	}
+	for _, text := range expected {
+		if !strings.Contains(doc, text) {
+			t.Errorf("Expected markdown to contain: '%s'\n%s", text, doc)
+		}
+	}
+}
+
+// TestParserWriteHTML tests to ensure the HTML documentation contains every
+// command within the tree, and that text is properly escaped.
+func TestParserWriteHTML(t *testing.T) {
+	cluster := NewParser("Manage clusters", emptyNamespace())
+	cluster.AddParser("scale", NewParser("Scale a cluster", emptyNamespace()))
+
+	p := NewParser("Deploy things", emptyNamespace()).Prog("prog").Usage("Deploy <things>")
+	p.AddOption(NewOption("region", "region", "Target region").Nargs("1").Action(Store).Default("$PROG_REGION"))
+	p.AddParser("cluster", cluster, "Manage clusters")
+
+	var buff bytes.Buffer
+	if err := p.WriteHTML(&buff); err != nil {
+		t.Fatalf("An unexpected error occurred: %s", err.Error())
+	}
+	doc := buff.String()
+
+	expected := []string{
+		"<section id=\"prog-cluster\">",
+		"<h3>prog cluster scale</h3>",
+		"<a href=\"#prog-cluster-scale\">scale</a>",
+		"<p>Deploy &lt;things&gt;</p>",
+		"<td>PROG_REGION</td>",
+	}
+	for _, text := range expected {
+		if !strings.Contains(doc, text) {
+			t.Errorf("Expected HTML to contain: '%s'\n%s", text, doc)
+		}
+	}
+}
@@ -1,84 +1,279 @@
 package argparse
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 )
 
+// Sentinel errors which can be used with errors.Is to determine the kind of
+// error returned while parsing, regardless of its details.
+var (
+	ErrConflict          = errors.New("conflicting options")
+	ErrInvalidChoice     = errors.New("invalid choice")
+	ErrInvalidOption     = errors.New("invalid option")
+	ErrInvalidType       = errors.New("invalid type")
+	ErrMissingDependency = errors.New("missing dependent option")
+	ErrMissingEnvVar     = errors.New("missing environmental variable")
+	ErrMissingOption     = errors.New("missing required option")
+	ErrMissingParser     = errors.New("missing command")
+	ErrTooFewArgs        = errors.New("too few arguments")
+	ErrValidation        = errors.New("invalid value")
+)
+
+// ParseError is implemented by errors which are caused by the arguments being
+// parsed. It provides the option which failed, if known, along with the
+// offending token and its index within the parsed arguments.
+type ParseError interface {
+	error
+	Option() *Option     // The option which failed to parse, or nil if unknown.
+	Token() string       // The offending argument, option name, or command.
+	Index() int          // The index of the token within the arguments, or -1 if not present.
+	Arguments() []string // The arguments originally provided to Parse.
+}
+
 // InvalidChoiceErr indicates that an argument is not among the valid choices
 // for the option.
 type InvalidChoiceErr struct {
-	opt Option
-	arg string
+	Opt      Option
+	Arg      string
+	ArgIndex int
+	Argv     []string
 }
 
 // Error will return a string error message for the InvalidChoiceErr
-func (err InvalidChoiceErr) Error() string {
-	msg := "%s: invalid choice \"%s\" (choose from: %s)"
-	return fmt.Sprintf(msg, err.opt.DisplayName(), err.arg, strings.Join(err.opt.ValidChoices, ", "))
+func (err InvalidChoiceErr) Error() string { return err.localize(English) }
 
+// localize returns the error message for the InvalidChoiceErr using the catalog.
+func (err InvalidChoiceErr) localize(c Catalog) string {
+	return sprintMessage(c, MsgInvalidChoice, 1, err.Opt.DisplayName(), err.Arg, strings.Join(err.Opt.ValidChoices, ", "))
 }
 
+// Is reports whether the target is the ErrInvalidChoice sentinel.
+func (err InvalidChoiceErr) Is(target error) bool { return target == ErrInvalidChoice }
+
+// Option returns the option which received the invalid choice.
+func (err InvalidChoiceErr) Option() *Option { return &err.Opt }
+
+// Token returns the invalid choice.
+func (err InvalidChoiceErr) Token() string { return err.Arg }
+
+// Index returns the index of the invalid choice within the parsed arguments.
+func (err InvalidChoiceErr) Index() int { return err.ArgIndex }
+
+// Arguments returns the arguments originally provided to Parse.
+func (err InvalidChoiceErr) Arguments() []string { return err.Argv }
+
 // InvalidParserNameErr indicates that a Command name has already been assigned and cannot be re-assigned.
 type InvalidParserNameErr struct {
-	name string
+	Name string
 }
 
 // Error will return a string error message for the InvalidParserNameErr
-func (err InvalidParserNameErr) Error() string {
-	msg := "invalid command name \"%s\""
-	return fmt.Sprintf(msg, err.name)
+func (err InvalidParserNameErr) Error() string { return err.localize(English) }
 
+// localize returns the error message for the InvalidParserNameErr using the catalog.
+func (err InvalidParserNameErr) localize(c Catalog) string {
+	return sprintMessage(c, MsgInvalidCommandName, 1, err.Name)
 }
 
 // InvalidFlagNameErr indicates that an argument with the provided public name
 // not exist.
 type InvalidFlagNameErr struct {
-	name string
+	Name string
 }
 
 // Error will return a string error message for the InvalidFlagNameErr
-func (err InvalidFlagNameErr) Error() string {
-	msg := "invalid flag name \"%s\""
-	return fmt.Sprintf(msg, err.name)
+func (err InvalidFlagNameErr) Error() string { return err.localize(English) }
 
+// localize returns the error message for the InvalidFlagNameErr using the catalog.
+func (err InvalidFlagNameErr) localize(c Catalog) string {
+	return sprintMessage(c, MsgInvalidFlagName, 1, err.Name)
 }
 
-// InvalidOptionErr indicates that an option is invalid.
+// InvalidOptionErr indicates that an option is invalid. Suggestions contains
+// the display names of similarly named options, if any.
 type InvalidOptionErr struct {
-	name string
+	Name        string
+	ArgIndex    int
+	Argv        []string
+	Suggestions []string
 }
 
 // Error will return a string error message for the InvalidFlagNameErr
-func (err InvalidOptionErr) Error() string {
-	msg := "invalid option \"%s\""
-	return fmt.Sprintf(msg, err.name)
+func (err InvalidOptionErr) Error() string { return err.localize(English) }
 
+// localize returns the error message for the InvalidOptionErr using the catalog.
+func (err InvalidOptionErr) localize(c Catalog) string {
+	return sprintMessage(c, MsgInvalidOption, 1, err.Name) + suggestionText(c, err.Suggestions)
 }
 
+// Is reports whether the target is the ErrInvalidOption sentinel.
+func (err InvalidOptionErr) Is(target error) bool { return target == ErrInvalidOption }
+
+// Option returns nil, as the option does not exist.
+func (err InvalidOptionErr) Option() *Option { return nil }
+
+// Token returns the name of the invalid option.
+func (err InvalidOptionErr) Token() string { return err.Name }
+
+// Index returns the index of the invalid option within the parsed arguments.
+func (err InvalidOptionErr) Index() int { return err.ArgIndex }
+
+// Arguments returns the arguments originally provided to Parse.
+func (err InvalidOptionErr) Arguments() []string { return err.Argv }
+
 // InvalidTypeErr indicates that an argument cannot be casted the the option's
-// expected type.
+// expected type. Err contains the underlying conversion error, if any.
 type InvalidTypeErr struct {
-	opt Option
-	arg string
+	Opt      Option
+	Arg      string
+	ArgIndex int
+	Argv     []string
+	Err      error
 }
 
 // Error will return a string error message for the InvalidTypeErr
-func (err InvalidTypeErr) Error() string {
-	msg := "%s: invalid %s value: \"%s\""
-	return fmt.Sprintf(msg, err.opt.DisplayName(), err.opt.ExpectedType.String(), err.arg)
+func (err InvalidTypeErr) Error() string { return err.localize(English) }
+
+// localize returns the error message for the InvalidTypeErr using the catalog.
+func (err InvalidTypeErr) localize(c Catalog) string {
+	return sprintMessage(c, MsgInvalidType, 1, err.Opt.DisplayName(), err.Opt.ExpectedType.String(), err.Arg)
 }
 
+// Is reports whether the target is the ErrInvalidType sentinel.
+func (err InvalidTypeErr) Is(target error) bool { return target == ErrInvalidType }
+
+// Unwrap returns the underlying conversion error.
+func (err InvalidTypeErr) Unwrap() error { return err.Err }
+
+// Option returns the option which received the invalid value.
+func (err InvalidTypeErr) Option() *Option { return &err.Opt }
+
+// Token returns the invalid value.
+func (err InvalidTypeErr) Token() string { return err.Arg }
+
+// Index returns the index of the invalid value within the parsed arguments.
+func (err InvalidTypeErr) Index() int { return err.ArgIndex }
+
+// Arguments returns the arguments originally provided to Parse.
+func (err InvalidTypeErr) Arguments() []string { return err.Argv }
+
+// ValidationErr indicates that an argument does not satisfy one of the option's
+// validators. Err contains the error returned by the validator.
+type ValidationErr struct {
+	Opt      Option
+	Arg      string
+	ArgIndex int
+	Argv     []string
+	Err      error
+}
+
+// Error will return a string error message for the ValidationErr
+func (err ValidationErr) Error() string { return err.localize(English) }
+
+// localize returns the error message for the ValidationErr using the catalog.
+func (err ValidationErr) localize(c Catalog) string {
+	reason := ""
+	if e, ok := err.Err.(localizedError); ok {
+		reason = e.localize(c)
+	} else if err.Err != nil {
+		reason = err.Err.Error()
+	}
+	return sprintMessage(c, MsgValidation, 1, err.Opt.DisplayName(), err.Arg, reason)
+}
+
+// Is reports whether the target is the ErrValidation sentinel.
+func (err ValidationErr) Is(target error) bool { return target == ErrValidation }
+
+// Unwrap returns the error returned by the validator.
+func (err ValidationErr) Unwrap() error { return err.Err }
+
+// Option returns the option which received the invalid value.
+func (err ValidationErr) Option() *Option { return &err.Opt }
+
+// Token returns the invalid value.
+func (err ValidationErr) Token() string { return err.Arg }
+
+// Index returns the index of the invalid value within the parsed arguments.
+func (err ValidationErr) Index() int { return err.ArgIndex }
+
+// Arguments returns the arguments originally provided to Parse.
+func (err ValidationErr) Arguments() []string { return err.Argv }
+
 // MissingEnvVarErr indicates that an environmental variable could not be found
 // with the provided variable name.
 type MissingEnvVarErr struct {
-	varName string
+	VarName string
 }
 
 // Error will return a string error message for the MissingEnvVarErr.
-func (err MissingEnvVarErr) Error() string {
-	msg := "missing environmental variable \"%s\""
-	return fmt.Sprintf(msg, err.varName)
+func (err MissingEnvVarErr) Error() string { return err.localize(English) }
+
+// localize returns the error message for the MissingEnvVarErr using the catalog.
+func (err MissingEnvVarErr) localize(c Catalog) string {
+	return sprintMessage(c, MsgMissingEnvVar, 1, err.VarName)
+}
+
+// Is reports whether the target is the ErrMissingEnvVar sentinel.
+func (err MissingEnvVarErr) Is(target error) bool { return target == ErrMissingEnvVar }
+
+// MultiError contains every error encountered while parsing, when the parser
+// collects errors rather than stopping at the first error.
+type MultiError struct {
+	Errors []error
+}
+
+// Error will return a string error message for the MultiError, containing the
+// message of each error on its own line.
+func (err MultiError) Error() string { return err.localize(English) }
+
+// localize returns the error message for the MultiError using the catalog.
+func (err MultiError) localize(c Catalog) string {
+	var msgs []string
+	for _, e := range err.Errors {
+		if localized, ok := e.(localizedError); ok {
+			msgs = append(msgs, localized.localize(c))
+		} else {
+			msgs = append(msgs, e.Error())
+		}
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// Unwrap returns the errors contained within the MultiError.
+func (err MultiError) Unwrap() []error { return err.Errors }
+
+// Is reports whether any of the contained errors match the target. This is
+// implemented, along with As, so that errors.Is and errors.As inspect the
+// contained errors on Go versions which do not unwrap multiple errors.
+func (err MultiError) Is(target error) bool {
+	for _, e := range err.Errors {
+		if errors.Is(e, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As finds the first contained error matching the target, and if one is found,
+// sets the target to that error and returns true.
+func (err MultiError) As(target interface{}) bool {
+	for _, e := range err.Errors {
+		if errors.As(e, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// isShowErr returns true if the provided error is a ShowHelpErr or ShowVersionErr.
+func isShowErr(err error) bool {
+	switch err.(type) {
+	case ShowHelpErr, ShowVersionErr:
+		return true
+	}
+	return false
 }
 
 // ShowHelpErr indicates that the program was instructed to show it's help text.
@@ -93,49 +288,365 @@ func (err ShowVersionErr) Error() string { return "" }
 
 // TooFewArgsErr indicated that not enough arguments were provided for the option.
 type TooFewArgsErr struct {
-	opt Option
+	Opt      Option
+	ArgIndex int
+	Argv     []string
 }
 
 // Error will return a string error message for the TooFewArgsErr
-func (err TooFewArgsErr) Error() string {
-	msg := "%s: too few arguments"
-	return fmt.Sprintf(msg, err.opt.DisplayName())
+func (err TooFewArgsErr) Error() string { return err.localize(English) }
+
+// localize returns the error message for the TooFewArgsErr using the catalog.
+func (err TooFewArgsErr) localize(c Catalog) string {
+	return sprintMessage(c, MsgTooFewArgs, 1, err.Opt.DisplayName())
 }
 
+// Is reports whether the target is the ErrTooFewArgs sentinel.
+func (err TooFewArgsErr) Is(target error) bool { return target == ErrTooFewArgs }
+
+// Option returns the option which received too few arguments.
+func (err TooFewArgsErr) Option() *Option { return &err.Opt }
+
+// Token returns the option's display name.
+func (err TooFewArgsErr) Token() string { return err.Opt.DisplayName() }
+
+// Index returns the index of the option within the parsed arguments.
+func (err TooFewArgsErr) Index() int { return err.ArgIndex }
+
+// Arguments returns the arguments originally provided to Parse.
+func (err TooFewArgsErr) Arguments() []string { return err.Argv }
+
 // MissingOneOrMoreArgsErr indicated that not enough arguments were provided,
 // when one or more arguments were expected, for the option.
 type MissingOneOrMoreArgsErr struct {
-	opt Option
+	Opt      Option
+	ArgIndex int
+	Argv     []string
 }
 
 // Error will return a string error message for the TooFewArgsErr
-func (err MissingOneOrMoreArgsErr) Error() string {
-	msg := "%s: at least one argument requireds"
-	return fmt.Sprintf(msg, err.opt.DisplayName())
+func (err MissingOneOrMoreArgsErr) Error() string { return err.localize(English) }
+
+// localize returns the error message for the MissingOneOrMoreArgsErr using the catalog.
+func (err MissingOneOrMoreArgsErr) localize(c Catalog) string {
+	return sprintMessage(c, MsgMissingOneOrMoreArgs, 1, err.Opt.DisplayName())
 }
 
+// Is reports whether the target is the ErrTooFewArgs sentinel.
+func (err MissingOneOrMoreArgsErr) Is(target error) bool { return target == ErrTooFewArgs }
+
+// Option returns the option which received too few arguments.
+func (err MissingOneOrMoreArgsErr) Option() *Option { return &err.Opt }
+
+// Token returns the option's display name.
+func (err MissingOneOrMoreArgsErr) Token() string { return err.Opt.DisplayName() }
+
+// Index returns the index of the option within the parsed arguments.
+func (err MissingOneOrMoreArgsErr) Index() int { return err.ArgIndex }
+
+// Arguments returns the arguments originally provided to Parse.
+func (err MissingOneOrMoreArgsErr) Arguments() []string { return err.Argv }
+
 // MissingParserErr indicated that commands were available, but none were used.
+// Suggestions contains the names of commands similar to the provided command,
+// if any.
 type MissingParserErr struct {
-	Parsers []SubParser
+	Parsers     []SubParser
+	Name        string
+	ArgIndex    int
+	Argv        []string
+	Suggestions []string
 }
 
 // Error will return a string error message for the MissingParserErr
-func (err MissingParserErr) Error() string {
-	var names []string
-	for _, subP := range err.Parsers {
-		names = append(names, subP.Name)
+func (err MissingParserErr) Error() string { return err.localize(English) }
+
+// localize returns the error message for the MissingParserErr using the catalog.
+func (err MissingParserErr) localize(c Catalog) string {
+	return sprintMessage(c, MsgMissingParser, len(err.Parsers), commandList(err.Parsers)) + suggestionText(c, err.Suggestions)
+}
+
+// Is reports whether the target is the ErrMissingParser sentinel.
+func (err MissingParserErr) Is(target error) bool { return target == ErrMissingParser }
+
+// Option returns nil, as commands are not options.
+func (err MissingParserErr) Option() *Option { return nil }
+
+// Token returns the unknown command name, or an empty string if no command
+// was provided.
+func (err MissingParserErr) Token() string { return err.Name }
+
+// Index returns the index of the unknown command within the parsed arguments.
+func (err MissingParserErr) Index() int { return err.ArgIndex }
+
+// Arguments returns the arguments originally provided to Parse.
+func (err MissingParserErr) Arguments() []string { return err.Argv }
+
+// positionError returns the provided error with the index of its offending
+// token and the arguments originally provided to Parse populated, if it is a
+// ParseError. Other errors are returned unmodified.
+func positionError(err error, index int, argv []string) error {
+	switch e := err.(type) {
+	case InvalidChoiceErr:
+		e.ArgIndex, e.Argv = index, argv
+		return e
+	case InvalidTypeErr:
+		e.ArgIndex, e.Argv = index, argv
+		return e
+	case ValidationErr:
+		e.ArgIndex, e.Argv = index, argv
+		return e
+	case InvalidOptionErr:
+		e.ArgIndex, e.Argv = index, argv
+		return e
+	case TooFewArgsErr:
+		e.ArgIndex, e.Argv = index, argv
+		return e
+	case MissingOneOrMoreArgsErr:
+		e.ArgIndex, e.Argv = index, argv
+		return e
+	case MissingParserErr:
+		e.ArgIndex, e.Argv = index, argv
+		return e
+	case MissingOptionErr:
+		e.Argv = argv
+		return e
+	case DependencyErr:
+		e.ArgIndex, e.Argv = index, argv
+		return e
+	case ConflictErr:
+		e.ArgIndex, e.Argv = index, argv
+		return e
+	case RequiredIfErr:
+		e.Argv = argv
+		return e
+	case MissingOneOfErr:
+		e.Argv = argv
+		return e
+	}
+
+	return err
+}
+
+// ErrorContext renders the arguments of the provided error, prefixed by the
+// program name, with a caret beneath the offending argument. For example:
+//
+//	prog deploy --replicas abc
+//	                       ^^^
+//
+// An empty string is returned if the error is not a ParseError, or if it does
+// not have an offending argument.
+func ErrorContext(prog string, err error) string {
+	parseErr, ok := err.(ParseError)
+	if !ok {
+		return ""
+	}
+
+	argv := parseErr.Arguments()
+	index := parseErr.Index()
+	if index < 0 || index >= len(argv) {
+		return ""
+	}
+
+	line := []string{prog}
+	indent := displayWidth(prog) + 1
+	if len(prog) == 0 {
+		line = nil
+		indent = 0
+	}
+
+	width := 0
+	for i, arg := range argv {
+		if len(arg) == 0 || strings.ContainsAny(arg, " \t\n\"'") {
+			arg = fmt.Sprintf("%q", arg)
+		}
+		if i < index {
+			indent = indent + displayWidth(arg) + 1
+		} else if i == index {
+			width = displayWidth(arg)
+		}
+		line = append(line, arg)
+	}
+
+	return join("", join(" ", line...), "\n", spacer(indent), strings.Repeat("^", width))
+}
+
+// suggestionText returns a message suggesting the provided names, using the
+// provided catalog, or an empty string if there are no suggestions.
+func suggestionText(c Catalog, suggestions []string) string {
+	if len(suggestions) == 0 {
+		return ""
+	}
+
+	var quoted []string
+	for _, suggestion := range suggestions {
+		quoted = append(quoted, fmt.Sprintf("\"%s\"", suggestion))
 	}
-	msg := "must use an available command: %s"
-	return fmt.Sprintf(msg, join("", "{", join(",", names...), "}"))
+
+	return sprintMessage(c, MsgSuggestion, len(quoted), strings.Join(quoted, ", "))
 }
 
 // MissingOptionErr indicated that an option was required but is missing.
 type MissingOptionErr struct {
-	name string
+	Name string
+	Opt  Option
+	Argv []string
 }
 
 // Error will return a string error message for the MissingOptionErr
-func (err MissingOptionErr) Error() string {
-	msg := "option \"%s\" required"
-	return fmt.Sprintf(msg, err.name)
+func (err MissingOptionErr) Error() string { return err.localize(English) }
+
+// localize returns the error message for the MissingOptionErr using the catalog.
+func (err MissingOptionErr) localize(c Catalog) string {
+	return sprintMessage(c, MsgMissingOption, 1, err.Name)
+}
+
+// Is reports whether the target is the ErrMissingOption sentinel.
+func (err MissingOptionErr) Is(target error) bool { return target == ErrMissingOption }
+
+// Option returns the missing option.
+func (err MissingOptionErr) Option() *Option { return &err.Opt }
+
+// Token returns the display name of the missing option.
+func (err MissingOptionErr) Token() string { return err.Name }
+
+// Index returns -1, as the option is not present within the parsed arguments.
+func (err MissingOptionErr) Index() int { return -1 }
+
+// Arguments returns the arguments originally provided to Parse.
+func (err MissingOptionErr) Arguments() []string { return err.Argv }
+
+// DependencyErr indicates that an option was provided without an option it
+// requires.
+type DependencyErr struct {
+	Opt      Option
+	Required string
+	ArgIndex int
+	Argv     []string
+}
+
+// Error will return a string error message for the DependencyErr
+func (err DependencyErr) Error() string { return err.localize(English) }
+
+// localize returns the error message for the DependencyErr using the catalog.
+func (err DependencyErr) localize(c Catalog) string {
+	return sprintMessage(c, MsgRequires, 1, constraintName(&err.Opt), err.Required)
+}
+
+// Is reports whether the target is the ErrMissingDependency sentinel.
+func (err DependencyErr) Is(target error) bool { return target == ErrMissingDependency }
+
+// Option returns the option which requires the missing option.
+func (err DependencyErr) Option() *Option { return &err.Opt }
+
+// Token returns the option's display name.
+func (err DependencyErr) Token() string { return err.Opt.DisplayName() }
+
+// Index returns the index of the option within the parsed arguments.
+func (err DependencyErr) Index() int { return err.ArgIndex }
+
+// Arguments returns the arguments originally provided to Parse.
+func (err DependencyErr) Arguments() []string { return err.Argv }
+
+// ConflictErr indicates that an option was provided along with an option it
+// conflicts with.
+type ConflictErr struct {
+	Opt      Option
+	Conflict string
+	ArgIndex int
+	Argv     []string
+}
+
+// Error will return a string error message for the ConflictErr
+func (err ConflictErr) Error() string { return err.localize(English) }
+
+// localize returns the error message for the ConflictErr using the catalog.
+func (err ConflictErr) localize(c Catalog) string {
+	return sprintMessage(c, MsgConflicts, 1, constraintName(&err.Opt), err.Conflict)
+}
+
+// Is reports whether the target is the ErrConflict sentinel.
+func (err ConflictErr) Is(target error) bool { return target == ErrConflict }
+
+// Option returns the conflicting option.
+func (err ConflictErr) Option() *Option { return &err.Opt }
+
+// Token returns the option's display name.
+func (err ConflictErr) Token() string { return err.Opt.DisplayName() }
+
+// Index returns the index of the option within the parsed arguments.
+func (err ConflictErr) Index() int { return err.ArgIndex }
+
+// Arguments returns the arguments originally provided to Parse.
+func (err ConflictErr) Arguments() []string { return err.Argv }
+
+// RequiredIfErr indicates that an option was required, due to the value of
+// another destination within the namespace, but is missing.
+type RequiredIfErr struct {
+	Opt   Option
+	Dest  string
+	Value string
+	Argv  []string
+}
+
+// Error will return a string error message for the RequiredIfErr
+func (err RequiredIfErr) Error() string { return err.localize(English) }
+
+// localize returns the error message for the RequiredIfErr using the catalog.
+func (err RequiredIfErr) localize(c Catalog) string {
+	return sprintMessage(c, MsgRequiredIf, 1, err.Opt.DisplayName(), err.Dest, err.Value)
+}
+
+// Is reports whether the target is the ErrMissingOption sentinel.
+func (err RequiredIfErr) Is(target error) bool { return target == ErrMissingOption }
+
+// Option returns the missing option.
+func (err RequiredIfErr) Option() *Option { return &err.Opt }
+
+// Token returns the display name of the missing option.
+func (err RequiredIfErr) Token() string { return err.Opt.DisplayName() }
+
+// Index returns -1, as the option is not present within the parsed arguments.
+func (err RequiredIfErr) Index() int { return -1 }
+
+// Arguments returns the arguments originally provided to Parse.
+func (err RequiredIfErr) Arguments() []string { return err.Argv }
+
+// MissingOneOfErr indicates that none of a group of options, of which at least
+// one is required, were provided.
+type MissingOneOfErr struct {
+	Opts []Option
+	Argv []string
+}
+
+// Error will return a string error message for the MissingOneOfErr
+func (err MissingOneOfErr) Error() string { return err.localize(English) }
+
+// localize returns the error message for the MissingOneOfErr using the catalog.
+func (err MissingOneOfErr) localize(c Catalog) string {
+	return sprintMessage(c, MsgMissingOneOf, len(err.Opts), err.names())
 }
+
+// names returns the names of the options, separated by commas.
+func (err MissingOneOfErr) names() string {
+	var opts []*Option
+	for i := range err.Opts {
+		opts = append(opts, &err.Opts[i])
+	}
+	return constraintNames(opts)
+}
+
+// Is reports whether the target is the ErrMissingOption sentinel.
+func (err MissingOneOfErr) Is(target error) bool { return target == ErrMissingOption }
+
+// Option returns nil, as no single option is missing.
+func (err MissingOneOfErr) Option() *Option { return nil }
+
+// Token returns the names of the options, separated by commas.
+func (err MissingOneOfErr) Token() string { return err.names() }
+
+// Index returns -1, as the options are not present within the parsed arguments.
+func (err MissingOneOfErr) Index() int { return -1 }
+
+// Arguments returns the arguments originally provided to Parse.
+func (err MissingOneOfErr) Arguments() []string { return err.Argv }
@@ -0,0 +1,134 @@
+package argparse
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+// Ensure each parsing error satisfies the ParseError interface.
+var (
+	_ ParseError = InvalidChoiceErr{}
+	_ ParseError = InvalidOptionErr{}
+	_ ParseError = InvalidTypeErr{}
+	_ ParseError = MissingOneOrMoreArgsErr{}
+	_ ParseError = MissingOptionErr{}
+	_ ParseError = MissingParserErr{}
+	_ ParseError = TooFewArgsErr{}
+)
+
+// TestErrorsIs tests to ensure each error matches its sentinel error, and only
+// its sentinel error, when using errors.Is.
+func TestErrorsIs(t *testing.T) {
+	table := []struct {
+		err      error
+		sentinel error
+	}{
+		{InvalidChoiceErr{}, ErrInvalidChoice},
+		{InvalidOptionErr{}, ErrInvalidOption},
+		{InvalidTypeErr{}, ErrInvalidType},
+		{MissingEnvVarErr{}, ErrMissingEnvVar},
+		{MissingOneOrMoreArgsErr{}, ErrTooFewArgs},
+		{MissingOptionErr{}, ErrMissingOption},
+		{TooFewArgsErr{}, ErrTooFewArgs},
+		{ValidationErr{}, ErrValidation},
+	}
+
+	for _, test := range table {
+		if !errors.Is(test.err, test.sentinel) {
+			t.Errorf("Expected %T to match the sentinel: %s", test.err, test.sentinel)
+		}
+		if errors.Is(test.err, ErrMissingParser) {
+			t.Errorf("Did not expect %T to match the sentinel: %s", test.err, ErrMissingParser)
+		}
+	}
+
+	if !errors.Is(MissingParserErr{}, ErrMissingParser) {
+		t.Error("Expected MissingParserErr to match its sentinel")
+	}
+}
+
+// TestInvalidTypeErr_Unwrap tests to ensure the underlying conversion error of
+// an InvalidTypeErr can be inspected.
+func TestInvalidTypeErr_Unwrap(t *testing.T) {
+	f := NewOption("count", "count", "a count").Type(reflect.Int)
+	err := ValidateType(*f, "abc")
+
+	if !errors.Is(err, strconv.ErrSyntax) {
+		t.Errorf("Expected the error to wrap strconv.ErrSyntax, but received: %v", err)
+	}
+
+	var typeErr InvalidTypeErr
+	if !errors.As(err, &typeErr) {
+		t.Fatalf("Expected an InvalidTypeErr, but received: %T", err)
+	}
+	if typeErr.Option().DestName != "count" || typeErr.Token() != "abc" {
+		t.Errorf("Unexpected option or token: %s, %s", typeErr.Option().DestName, typeErr.Token())
+	}
+}
+
+// TestParseError_Index tests to ensure errors returned while parsing report the
+// index of the offending argument, including within sub-parsers.
+func TestParseError_Index(t *testing.T) {
+	var err error
+	callback := func(p *Parser, ns *Namespace, args []string, e error) {
+		err = e
+	}
+
+	child := NewParser("child", callback)
+	child.AddOption(NewOption("size", "size", "a size").Nargs("1").Action(Store).Choices("s", "m", "l"))
+	p := NewParser("program", callback)
+	p.AddParser("child", child)
+
+	child.Parse("--size", "xl")
+	if parseErr, ok := err.(ParseError); !ok || parseErr.Index() != 1 || parseErr.Token() != "xl" {
+		t.Errorf("Expected the token \"xl\" at index 1, but received: %#v", err)
+	}
+
+	p.Parse("child", "--size", "xl")
+	if parseErr, ok := err.(ParseError); !ok || parseErr.Index() != 2 {
+		t.Errorf("Expected the token \"xl\" at index 2, but received: %#v", err)
+	}
+
+	p.Parse("child", "--sizes")
+	if parseErr, ok := err.(ParseError); !ok || parseErr.Index() != 1 || parseErr.Option() != nil {
+		t.Errorf("Expected the unknown option at index 1, but received: %#v", err)
+	}
+
+	p.Parse()
+	if parseErr, ok := err.(ParseError); !ok || parseErr.Index() != -1 {
+		t.Errorf("Expected the missing command at index -1, but received: %#v", err)
+	}
+}
+
+// TestErrorContext tests to ensure errors caused by parsed arguments are
+// rendered with a caret beneath the offending argument.
+func TestErrorContext(t *testing.T) {
+	var err error
+	callback := func(p *Parser, ns *Namespace, args []string, e error) {
+		err = e
+	}
+
+	deploy := NewParser("deploy", callback)
+	deploy.AddOption(NewOption("replicas", "replicas", "Replicas").Nargs("1").Action(Store).Type(reflect.Int))
+	deploy.AddOption(NewOption("n name", "name", "Name").Nargs("1").Action(Store))
+	p := NewParser("program", callback).Prog("prog")
+	p.AddParser("deploy", deploy)
+
+	p.Parse("deploy", "-n", "my app", "--replicas", "abc")
+	expected := "prog deploy -n \"my app\" --replicas abc\n                                   ^^^"
+	if context := ErrorContext("prog", err); context != expected {
+		t.Errorf("Expected the error context:\n%s\nbut received:\n%s", expected, context)
+	}
+
+	p.Parse("deploy", "--replica", "3")
+	expected = "prog deploy --replica 3\n            ^^^^^^^^^"
+	if context := ErrorContext("prog", err); context != expected {
+		t.Errorf("Expected the error context:\n%s\nbut received:\n%s", expected, context)
+	}
+
+	if context := ErrorContext("prog", MissingOptionErr{Name: "name"}); len(context) != 0 {
+		t.Errorf("Expected no error context, but received:\n%s", context)
+	}
+}
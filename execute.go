@@ -0,0 +1,66 @@
+package argparse
+
+import (
+	"context"
+	"os"
+	"os/signal"
+)
+
+// HandlerFunc runs a command using the namespace of parsed options. The context
+// is cancelled if the program is interrupted while the handler is running.
+type HandlerFunc func(ctx context.Context, ns *Namespace) error
+
+// Handler sets the function used to run the parser's command when executed
+// using Execute. Commands without a handler of their own are run using their
+// nearest parent's handler.
+func (p *Parser) Handler(handler HandlerFunc) *Parser {
+	p.CommandHandler = handler
+	return p
+}
+
+// handler returns the handler used to run the parser's command, or nil if
+// neither the parser nor its parents have a handler.
+func (p *Parser) handler() HandlerFunc {
+	for parser := p; parser != nil; parser = parser.parent {
+		if parser.CommandHandler != nil {
+			return parser.CommandHandler
+		}
+	}
+	return nil
+}
+
+// Execute parses the provided arguments, resolving the chain of commands, and
+// runs the handler of the deepest command selected, wrapped by the middleware of
+// each command in the chain, returning its error. The handler receives the
+// merged namespace of every parser in the chain, and a context derived from the
+// provided context which is cancelled upon receiving an interrupt signal.
+//
+// Parsing errors are returned without running a handler, unless the parser
+// exits on errors. No error is returned after displaying help or version text.
+// Callbacks are not called when executing. Files opened while parsing are closed
+// once the handler returns.
+func (p *Parser) Execute(ctx context.Context, args []string) error {
+	parser, rest, err := p.parse(args, 0, nil, nil)
+	defer p.Close()
+	if err == nil {
+		err = parser.postParse(rest)
+	}
+	if err != nil && parser.exitOnError() {
+		parser.exitWithError(err)
+	}
+	if isShowErr(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	handler := parser.handler()
+	if handler == nil {
+		return nil
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+
+	return parser.wrap(handler)(ctx, parser.Namespace)
+}
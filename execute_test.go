@@ -0,0 +1,91 @@
+package argparse
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"runtime"
+	"testing"
+	"time"
+)
+
+// TestParserExecute tests to ensure the handler of the deepest command is run
+// using the merged namespace, and that its error is returned.
+func TestParserExecute(t *testing.T) {
+	errFailed := errors.New("failed")
+	var ran string
+	var replicas string
+
+	scale := NewParser("scale", nil).Handler(func(ctx context.Context, ns *Namespace) error {
+		ran, replicas = "scale", ns.String("replicas")
+		return errFailed
+	})
+	scale.AddOption(NewOption("replicas", "replicas", "Replicas").Nargs("1").Action(Store).Persistent())
+	status := NewParser("status", nil)
+
+	cluster := NewParser("cluster", nil).Handler(func(ctx context.Context, ns *Namespace) error {
+		ran = "cluster"
+		return nil
+	})
+	cluster.AddParser("scale", scale)
+	cluster.AddParser("status", status)
+
+	p := NewParser("program", nil).Output(new(bytes.Buffer))
+	p.AddHelp()
+	p.AddParser("cluster", cluster)
+
+	if err := p.Execute(context.Background(), []string{"cluster", "scale", "--replicas", "3"}); err != errFailed {
+		t.Errorf("Expected the handler's error, but received: %v", err)
+	}
+	if ran != "scale" || replicas != "3" {
+		t.Errorf("Expected the \"scale\" handler with 3 replicas, but ran \"%s\" with \"%s\"", ran, replicas)
+	}
+
+	if err := p.Execute(context.Background(), []string{"cluster", "status"}); err != nil {
+		t.Errorf("An unexpected error occurred: %v", err)
+	}
+	if ran != "cluster" {
+		t.Errorf("Expected the parent's handler to run, but ran \"%s\"", ran)
+	}
+
+	ran = ""
+	if err := p.Execute(context.Background(), []string{"cluster", "bogus"}); !errors.Is(err, ErrMissingParser) {
+		t.Errorf("Expected a missing command error, but received: %v", err)
+	}
+	if err := p.Execute(context.Background(), []string{"--help"}); err != nil {
+		t.Errorf("Expected no error after displaying help, but received: %v", err)
+	}
+	if len(ran) > 0 {
+		t.Errorf("Expected no handler to run, but ran \"%s\"", ran)
+	}
+}
+
+// TestParserExecute_Interrupt tests to ensure the handler's context is cancelled
+// when the program is interrupted.
+func TestParserExecute_Interrupt(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Interrupt signals cannot be sent on Windows")
+	}
+
+	p := NewParser("program", nil).Handler(func(ctx context.Context, ns *Namespace) error {
+		proc, err := os.FindProcess(os.Getpid())
+		if err != nil {
+			return err
+		}
+		if err := proc.Signal(os.Interrupt); err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(5 * time.Second):
+			return nil
+		}
+	})
+
+	if err := p.Execute(context.Background(), nil); err != context.Canceled {
+		t.Errorf("Expected the context to be cancelled, but received: %v", err)
+	}
+}
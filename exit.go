@@ -0,0 +1,86 @@
+package argparse
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+// exit terminates the program with the provided status code. It is a variable
+// so that it can be replaced during testing.
+var exit = os.Exit
+
+// Conventional exit status codes used when a parser exits on errors.
+const (
+	ExitSuccess = 0 // Help or version text was requested and displayed.
+	ExitUsage   = 2 // The provided arguments could not be parsed.
+)
+
+// ErrorCode pairs an error with the exit status code to use when a parser
+// exits due to a matching error.
+type ErrorCode struct {
+	Err  error
+	Code int
+}
+
+// ExitOnError sets whether the parser exits the program upon encountering an
+// error, rather than passing the error to its callback. When exiting, the
+// parser's usage line and the error are written to the parser's error writer,
+// and the program exits with a status of 2. After displaying help or version
+// text, the program exits with a status of 0. Sub-parsers will exit on errors
+// if any of their parents do.
+func (p *Parser) ExitOnError(exitOnError bool) *Parser {
+	p.ExitOnErr = exitOnError
+	return p
+}
+
+// ExitCode sets the exit status code used when the parser exits due to an error
+// matching the provided error, as reported by errors.Is. Sentinel errors such as
+// ErrInvalidChoice can be used to match entire kinds of errors. Codes are
+// checked in the order they were added, and are inherited by sub-parsers.
+func (p *Parser) ExitCode(err error, code int) *Parser {
+	p.ErrorCodes = append(p.ErrorCodes, ErrorCode{Err: err, Code: code})
+	return p
+}
+
+// exitOnError returns true if the parser, or any of its parents, exits upon
+// encountering errors.
+func (p *Parser) exitOnError() bool {
+	for parser := p; parser != nil; parser = parser.parent {
+		if parser.ExitOnErr {
+			return true
+		}
+	}
+	return false
+}
+
+// exitCode returns the exit status code for the provided error.
+func (p *Parser) exitCode(err error) int {
+	for parser := p; parser != nil; parser = parser.parent {
+		for _, errCode := range parser.ErrorCodes {
+			if errors.Is(err, errCode.Err) {
+				return errCode.Code
+			}
+		}
+	}
+
+	if isShowErr(err) {
+		return ExitSuccess
+	}
+	return ExitUsage
+}
+
+// exitWithError exits the program with the exit status code for the provided
+// error. Unless help or version text was displayed, the parser's usage line and
+// the error, along with the context of the offending argument, are first written
+// to the parser's error writer.
+func (p *Parser) exitWithError(err error) {
+	if !isShowErr(err) {
+		w := p.errWriter()
+		theme := p.styleFor(w)
+		fmt.Fprintln(w, p.usageLine(theme))
+		fmt.Fprintln(w, p.formatError(err, join(" ", p.ProgramName+":", theme.errorPrefix(message(p.catalog(), MsgError, 1)))))
+	}
+
+	exit(p.exitCode(err))
+}
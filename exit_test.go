@@ -0,0 +1,84 @@
+package argparse
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// captureExit replaces the exit function for the duration of the test, returning
+// a pointer to the most recent exit status code, or -1 if exit was not called.
+func captureExit(t *testing.T) *int {
+	code := -1
+	oldExit := exit
+	exit = func(c int) { code = c }
+	t.Cleanup(func() { exit = oldExit })
+	return &code
+}
+
+// TestParserExitOnError tests to ensure the parser exits with the conventional
+// status codes, and writes the usage line and error when exiting due to an error.
+func TestParserExitOnError(t *testing.T) {
+	code := captureExit(t)
+
+	var out, errOut bytes.Buffer
+	child := NewParser("child", emptyNamespace())
+	child.AddHelp()
+	p := NewParser("program", emptyNamespace()).Prog("prog").Output(&out).ErrOutput(&errOut)
+	p.AddParser("child", child)
+
+	p.Parse("bogus")
+	if *code != -1 {
+		t.Errorf("Did not expect the program to exit, but exited with: %d", *code)
+	}
+
+	p.ExitOnError(true).Parse("bogus")
+	if *code != ExitUsage {
+		t.Errorf("Expected an exit status of %d, but received: %d", ExitUsage, *code)
+	}
+	expected := "usage: prog {child}\nprog: error: must use an available command: {child}\nprog bogus\n     ^^^^^\n"
+	if errOut.String() != expected {
+		t.Errorf("Expected the error output:\n%q\nbut received:\n%q", expected, errOut.String())
+	}
+
+	p.Parse("child", "--help")
+	if *code != ExitSuccess {
+		t.Errorf("Expected an exit status of %d, but received: %d", ExitSuccess, *code)
+	}
+	if !strings.HasPrefix(out.String(), "usage:") {
+		t.Errorf("Expected help text to be displayed, but received: %s", out.String())
+	}
+}
+
+// TestParserExitCode tests to ensure custom exit status codes are used for
+// matching errors.
+func TestParserExitCode(t *testing.T) {
+	code := captureExit(t)
+
+	var errOut bytes.Buffer
+	p := NewParser("program", emptyNamespace()).ErrOutput(&errOut).ExitOnError(true)
+	p.AddOption(NewOption("size", "size", "Size").Nargs("1").Action(Store).Choices("s", "m"))
+	p.ExitCode(ErrInvalidOption, 64).ExitCode(ErrInvalidChoice, 65)
+
+	p.Parse("--bogus")
+	if *code != 64 {
+		t.Errorf("Expected an exit status of 64, but received: %d", *code)
+	}
+
+	p.Parse("--size", "xl")
+	if *code != 65 {
+		t.Errorf("Expected an exit status of 65, but received: %d", *code)
+	}
+}
+
+// TestParserGetUsage tests to ensure the usage line of the parser's help text is
+// returned.
+func TestParserGetUsage(t *testing.T) {
+	p := NewParser("program", emptyNamespace()).Prog("prog")
+	p.AddHelp()
+	p.AddOption(NewArg("name", "name", "A name"))
+
+	if usage := p.GetUsage(); usage != "usage: prog [-h] [name NAME]" {
+		t.Errorf("Unexpected usage line: %s", usage)
+	}
+}
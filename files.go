@@ -0,0 +1,216 @@
+package argparse
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+// Stream is the argument representing stdin when reading files, or stdout when
+// writing files.
+const Stream = "-"
+
+// ExistingFile requires the option's arguments to be paths of existing files
+// which are not directories, or "-" for stdin.
+func (f *Option) ExistingFile() *Option {
+	return f.Validate(func(arg string) error {
+		if arg == Stream {
+			return nil
+		}
+		info, err := os.Stat(arg)
+		if err != nil {
+			return fileErr(err)
+		} else if info.IsDir() {
+			return validatorErr{key: MsgIsDir, count: 1}
+		}
+		return nil
+	})
+}
+
+// ExistingDir requires the option's arguments to be paths of existing
+// directories.
+func (f *Option) ExistingDir() *Option {
+	return f.Validate(func(arg string) error {
+		info, err := os.Stat(arg)
+		if err != nil {
+			return fileErr(err)
+		} else if !info.IsDir() {
+			return validatorErr{key: MsgNotDir, count: 1}
+		}
+		return nil
+	})
+}
+
+// NewFile requires the option's arguments to be paths of files which do not
+// yet exist, within existing directories, or "-" for stdout. Opened files are
+// created for writing, failing if the file has since been created.
+func (f *Option) NewFile() *Option {
+	f.FileFlag = os.O_WRONLY | os.O_CREATE | os.O_EXCL
+	return f.Validate(func(arg string) error {
+		if arg == Stream {
+			return nil
+		}
+		if _, err := os.Stat(arg); err == nil {
+			return validatorErr{key: MsgFileExists, count: 1, err: os.ErrExist}
+		}
+		return existingParent(arg)
+	})
+}
+
+// Readable requires the option's arguments to be paths of files which can be
+// read, or "-" for stdin. Files are not opened while validating, so that named
+// pipes are left untouched. Opened files are opened for reading.
+func (f *Option) Readable() *Option {
+	f.FileFlag = os.O_RDONLY
+	return f.Validate(func(arg string) error {
+		if arg == Stream {
+			return nil
+		}
+		info, err := os.Stat(arg)
+		if err != nil {
+			return fileErr(err)
+		} else if info.IsDir() {
+			return validatorErr{key: MsgIsDir, count: 1}
+		}
+		return fileErr(access(arg, readAccess))
+	})
+}
+
+// Writable requires the option's arguments to be paths of files which can be
+// opened for writing, or "-" for stdout. Files which do not exist must be within
+// an existing directory. Files are not opened while validating. Opened files are
+// created or truncated for writing.
+func (f *Option) Writable() *Option {
+	f.FileFlag = os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	return f.Validate(func(arg string) error {
+		if arg == Stream {
+			return nil
+		}
+		info, err := os.Stat(arg)
+		if os.IsNotExist(err) {
+			return existingParent(arg)
+		} else if err != nil {
+			return fileErr(err)
+		} else if info.IsDir() {
+			return validatorErr{key: MsgIsDir, count: 1}
+		}
+		return fileErr(access(arg, writeAccess))
+	})
+}
+
+// Open sets the option's arguments to be opened once parsed, storing the opened
+// *os.File, or a []*os.File for multiple arguments, within the namespace. Files
+// are opened using the option's FileFlag, with "-" opening stdin or stdout.
+// Opened files are closed using Parser.Close.
+func (f *Option) Open() *Option {
+	f.OpensFile = true
+	return f
+}
+
+// existingParent returns an error if the directory containing the path does
+// not exist.
+func existingParent(path string) error {
+	info, err := os.Stat(filepath.Dir(path))
+	if err != nil {
+		return fileErr(err)
+	} else if !info.IsDir() {
+		return validatorErr{key: MsgNotDir, count: 1}
+	}
+	return nil
+}
+
+// fileErr returns a localizable error for the provided filesystem error, where
+// the kind of error is known.
+func fileErr(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, os.ErrExist):
+		return validatorErr{key: MsgFileExists, count: 1, err: err}
+	case errors.Is(err, os.ErrNotExist):
+		return validatorErr{key: MsgNoSuchFile, count: 1, err: err}
+	case errors.Is(err, os.ErrPermission):
+		return validatorErr{key: MsgPermissionDenied, count: 1, err: err}
+	}
+	return err
+}
+
+// openFile opens the provided path using the option's FileFlag, or returns
+// stdin or stdout for "-".
+func openFile(f *Option, path string) (*os.File, error) {
+	if path == Stream {
+		if f.FileFlag&(os.O_WRONLY|os.O_RDWR) != 0 {
+			return os.Stdout, nil
+		}
+		return os.Stdin, nil
+	}
+	return os.OpenFile(path, f.FileFlag, 0666)
+}
+
+// openFiles opens the arguments of the parser's options which open files,
+// replacing their values within the namespace with the opened files. Options
+// without a value, or whose files were already opened, are skipped.
+func (p *Parser) openFiles(provided map[*Option]int, argv []string) []error {
+	var errs []error
+	root := p.root()
+
+	open := func(option *Option, path string) *os.File {
+		file, err := openFile(option, path)
+		if err != nil {
+			index, ok := provided[option]
+			if !ok {
+				index = -1
+			}
+			errs = append(errs, positionError(ValidationErr{Opt: *option, Arg: path, Err: fileErr(err)}, index, argv))
+			return nil
+		}
+		if file != os.Stdin && file != os.Stdout {
+			root.openedFiles = append(root.openedFiles, file)
+		}
+		return file
+	}
+
+	for _, option := range append(p.Options[:len(p.Options):len(p.Options)], p.persistentOptions()...) {
+		if !option.OpensFile {
+			continue
+		}
+
+		switch value := p.Namespace.Get(option.DestName).(type) {
+		case string:
+			if len(value) == 0 {
+				continue
+			}
+			if file := open(option, value); file != nil {
+				p.Namespace.Set(option.DestName, file)
+			}
+		case []string:
+			var files []*os.File
+			for _, path := range value {
+				if file := open(option, path); file != nil {
+					files = append(files, file)
+				}
+			}
+			if len(files) == len(value) {
+				p.Namespace.Set(option.DestName, files)
+			}
+		}
+	}
+
+	return errs
+}
+
+// Close closes every file opened while parsing by the parser, its parents, and
+// their sub-parsers, returning the first error encountered. Stdin and stdout
+// are not closed.
+func (p *Parser) Close() error {
+	root := p.root()
+
+	var err error
+	for _, file := range root.openedFiles {
+		if closeErr := file.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}
+	root.openedFiles = nil
+	return err
+}
@@ -0,0 +1,220 @@
+package argparse
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// validateFile returns the message of the error returned by the option's
+// validators for the provided path, or an empty string if it is valid.
+func validateFile(opt *Option, path string) string {
+	if err := ValidateValue(*opt, path); err != nil {
+		return err.(ValidationErr).Err.Error()
+	}
+	return ""
+}
+
+// TestOptionExistingFile tests to ensure only paths of existing files, or "-",
+// are accepted.
+func TestOptionExistingFile(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "data.csv")
+	if err := os.WriteFile(file, []byte("a,b\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	opt := NewOption("in", "in", "Input").ExistingFile()
+
+	for path, expected := range map[string]string{
+		file:                          "",
+		Stream:                        "",
+		dir:                           "is a directory",
+		filepath.Join(dir, "missing"): "no such file or directory",
+	} {
+		if message := validateFile(opt, path); message != expected {
+			t.Errorf("%s: Expected the error \"%s\", but received \"%s\"", path, expected, message)
+		}
+	}
+
+	if err := ValidateValue(*opt, filepath.Join(dir, "missing")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Expected the underlying error to be unwrapped, but received: %v", err)
+	}
+}
+
+// TestOptionExistingDir tests to ensure only paths of existing directories are
+// accepted.
+func TestOptionExistingDir(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "data.csv")
+	if err := os.WriteFile(file, []byte("a,b\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	opt := NewOption("dir", "dir", "Directory").ExistingDir()
+
+	for path, expected := range map[string]string{
+		dir:                           "",
+		file:                          "not a directory",
+		filepath.Join(dir, "missing"): "no such file or directory",
+	} {
+		if message := validateFile(opt, path); message != expected {
+			t.Errorf("%s: Expected the error \"%s\", but received \"%s\"", path, expected, message)
+		}
+	}
+}
+
+// TestOptionNewFile tests to ensure only paths of files which do not exist,
+// within existing directories, are accepted, and that files created after
+// being validated are not truncated when opened.
+func TestOptionNewFile(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "data.csv")
+	if err := os.WriteFile(file, []byte("a,b\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	missing := filepath.Join(dir, "missing")
+
+	for path, expected := range map[string]string{
+		missing:                           "",
+		Stream:                            "",
+		file:                              "file already exists",
+		filepath.Join(missing, "out.csv"): "no such file or directory",
+	} {
+		if message := validateFile(NewOption("out", "out", "Output").NewFile(), path); message != expected {
+			t.Errorf("%s: Expected the error \"%s\", but received \"%s\"", path, expected, message)
+		}
+	}
+
+	var err error
+	p := NewParser("program", func(p *Parser, ns *Namespace, args []string, e error) {
+		err = e
+	})
+	p.AddOption(NewOption("out", "out", "Output").Nargs("1").Action(Store).NewFile().Open().Validate(func(arg string) error {
+		// Create the file once it has been validated, but before it is opened.
+		return os.WriteFile(arg, []byte("keep"), 0644)
+	}))
+	defer p.Close()
+
+	p.Parse("--out", missing)
+	if !errors.Is(err, os.ErrExist) || err.Error() != "--out: invalid value \""+missing+"\": file already exists" {
+		t.Errorf("Expected the file to already exist, but received: %v", err)
+	}
+	if content, _ := os.ReadFile(missing); string(content) != "keep" {
+		t.Errorf("Expected the existing file to be kept, but received \"%s\"", content)
+	}
+}
+
+// TestOptionReadable tests to ensure only paths of readable files, or "-", are
+// accepted, according to the permissions of the current user.
+func TestOptionReadable(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "data.csv")
+	if err := os.WriteFile(file, []byte("a,b\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	private := filepath.Join(dir, "private.csv")
+	if err := os.WriteFile(private, []byte("a,b\n"), 0200); err != nil {
+		t.Fatal(err)
+	}
+
+	// Files without read permissions may still be read by the superuser.
+	denied := "permission denied"
+	if os.Geteuid() == 0 {
+		denied = ""
+	}
+
+	for path, expected := range map[string]string{
+		file:                          "",
+		Stream:                        "",
+		dir:                           "is a directory",
+		private:                       denied,
+		filepath.Join(dir, "missing"): "no such file or directory",
+	} {
+		if message := validateFile(NewOption("in", "in", "Input").Readable(), path); message != expected {
+			t.Errorf("%s: Expected the error \"%s\", but received \"%s\"", path, expected, message)
+		}
+	}
+}
+
+// TestOptionWritable tests to ensure only paths of writable files, or "-", are
+// accepted, without creating or modifying them.
+func TestOptionWritable(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "data.csv")
+	if err := os.WriteFile(file, []byte("a,b\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	missing := filepath.Join(dir, "missing")
+
+	for path, expected := range map[string]string{
+		file:                              "",
+		missing:                           "",
+		Stream:                            "",
+		dir:                               "is a directory",
+		filepath.Join(missing, "out.csv"): "no such file or directory",
+	} {
+		if message := validateFile(NewOption("out", "out", "Output").Writable(), path); message != expected {
+			t.Errorf("%s: Expected the error \"%s\", but received \"%s\"", path, expected, message)
+		}
+	}
+
+	if _, err := os.Stat(missing); !os.IsNotExist(err) {
+		t.Error("Did not expect validating a writable file to create it")
+	}
+	if content, _ := os.ReadFile(file); string(content) != "a,b\n" {
+		t.Errorf("Did not expect validating a writable file to modify it, but received \"%s\"", content)
+	}
+}
+
+// TestOptionOpen tests to ensure opened files are stored within the namespace
+// and closed by the parser.
+func TestOptionOpen(t *testing.T) {
+	dir := t.TempDir()
+	input := filepath.Join(dir, "input.txt")
+	if err := os.WriteFile(input, []byte("hello"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	output := filepath.Join(dir, "output.txt")
+
+	var (
+		in, out *os.File
+		sources []*os.File
+	)
+	p := NewParser("program", nil).Handler(func(ctx context.Context, ns *Namespace) error {
+		in, _ = ns.Get("in").(*os.File)
+		out, _ = ns.Get("out").(*os.File)
+		sources, _ = ns.Get("sources").([]*os.File)
+		_, err := out.WriteString("world")
+		return err
+	})
+	p.AddOptions(
+		NewOption("in", "in", "Input").Nargs("1").Action(Store).Readable().Open(),
+		NewOption("out", "out", "Output").Nargs("1").Action(Store).NewFile().Open(),
+		NewOption("sources", "sources", "Sources").Nargs("+").Action(Store).ExistingFile().Open(),
+		NewOption("log", "log", "Log").Nargs("1").Action(Store).Writable().Open().Default(Stream),
+	)
+
+	if err := p.Execute(context.Background(), []string{"--in", input, "--out", output, "--sources", input, Stream}); err != nil {
+		t.Fatalf("An unexpected error occurred: %v", err)
+	}
+
+	if in == nil || out == nil || len(sources) != 2 {
+		t.Fatalf("Expected opened files within the namespace, but received: %v, %v, %v", in, out, sources)
+	}
+	if sources[1] != os.Stdin {
+		t.Error("Expected \"-\" to open stdin")
+	}
+	if p.Namespace.Get("log") != os.Stdout {
+		t.Error("Expected \"-\" to open stdout for a writable file")
+	}
+	if _, err := in.Read(make([]byte, 1)); err == nil {
+		t.Error("Expected the opened files to be closed after executing")
+	}
+	if content, _ := os.ReadFile(output); string(content) != "world" {
+		t.Errorf("Expected the output file to contain \"world\", but received \"%s\"", content)
+	}
+	if err := p.Close(); err != nil {
+		t.Errorf("Did not expect an error closing the parser twice: %v", err)
+	}
+}
@@ -0,0 +1,64 @@
+//go:build linux || darwin || dragonfly || freebsd || netbsd || openbsd
+
+package argparse
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// TestOptionReadable_Pipe tests to ensure named pipes are validated as readable
+// and writable without being opened, which would block until the other end of
+// the pipe is opened.
+func TestOptionReadable_Pipe(t *testing.T) {
+	fifo := filepath.Join(t.TempDir(), "fifo")
+	if err := syscall.Mkfifo(fifo, 0600); err != nil {
+		t.Skipf("Named pipes are unsupported: %v", err)
+	}
+
+	done := make(chan [2]string, 1)
+	go func() {
+		done <- [2]string{
+			validateFile(NewOption("in", "in", "Input").Readable(), fifo),
+			validateFile(NewOption("out", "out", "Output").Writable(), fifo),
+		}
+	}()
+
+	select {
+	case messages := <-done:
+		if messages[0] != "" || messages[1] != "" {
+			t.Errorf("Expected the named pipe to be readable and writable, but received: %q", messages)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected validating a named pipe not to open it")
+	}
+}
+
+// TestOptionReadable_Owner tests to ensure the permissions of files are checked
+// against the current user, rather than only their mode bits.
+func TestOptionReadable_Owner(t *testing.T) {
+	if os.Geteuid() != 0 {
+		t.Skip("Changing the owner of a file requires the superuser")
+	}
+
+	file := filepath.Join(t.TempDir(), "data.csv")
+	if err := os.WriteFile(file, []byte("a,b\n"), 0400); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chown(file, 65534, 65534); err != nil {
+		t.Skipf("Unable to change the owner of the file: %v", err)
+	}
+	if err := os.Chmod(file, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	if message := validateFile(NewOption("in", "in", "Input").Readable(), file); message != "" {
+		t.Errorf("Expected the superuser to read a file without permissions, but received \"%s\"", message)
+	}
+	if message := validateFile(NewOption("out", "out", "Output").Writable(), file); message != "" {
+		t.Errorf("Expected the superuser to write a file without permissions, but received \"%s\"", message)
+	}
+}
@@ -0,0 +1,297 @@
+package argparse
+
+import (
+	"io"
+	"strings"
+)
+
+// HelpFormatter is used by a parser to render its help text. A custom layout
+// can be used by providing an implementation to Parser.Formatter.
+type HelpFormatter interface {
+	FormatHelp(p *Parser) string
+}
+
+// DefaultHelpFormatter renders help text by wrapping the parser's description,
+// epilog, and each option's help text to fit the width of the screen. Existing
+// whitespace and newlines within the text are collapsed.
+type DefaultHelpFormatter struct{}
+
+// FormatHelp returns the help text for the provided parser.
+func (f DefaultHelpFormatter) FormatHelp(p *Parser) string {
+	return f.layout().format(p, p.outWriter())
+}
+
+// layout returns the layout used by the formatter.
+func (f DefaultHelpFormatter) layout() helpLayout { return helpLayout{} }
+
+// RawDescriptionHelpFormatter renders help text like the DefaultHelpFormatter,
+// except that the parser's description and epilog text are left as-is.
+type RawDescriptionHelpFormatter struct{}
+
+// FormatHelp returns the help text for the provided parser.
+func (f RawDescriptionHelpFormatter) FormatHelp(p *Parser) string {
+	return f.layout().format(p, p.outWriter())
+}
+
+// layout returns the layout used by the formatter.
+func (f RawDescriptionHelpFormatter) layout() helpLayout {
+	return helpLayout{rawDescription: true}
+}
+
+// RawTextHelpFormatter renders help text like the RawDescriptionHelpFormatter,
+// except that newlines within each option's help text are also preserved.
+type RawTextHelpFormatter struct{}
+
+// FormatHelp returns the help text for the provided parser.
+func (f RawTextHelpFormatter) FormatHelp(p *Parser) string {
+	return f.layout().format(p, p.outWriter())
+}
+
+// layout returns the layout used by the formatter.
+func (f RawTextHelpFormatter) layout() helpLayout {
+	return helpLayout{rawDescription: true, rawText: true}
+}
+
+// ArgumentDefaultsHelpFormatter renders help text like the DefaultHelpFormatter,
+// except that each option's default value, if any, is appended to its help text.
+type ArgumentDefaultsHelpFormatter struct{}
+
+// FormatHelp returns the help text for the provided parser.
+func (f ArgumentDefaultsHelpFormatter) FormatHelp(p *Parser) string {
+	return f.layout().format(p, p.outWriter())
+}
+
+// layout returns the layout used by the formatter.
+func (f ArgumentDefaultsHelpFormatter) layout() helpLayout {
+	return helpLayout{showDefaults: true}
+}
+
+// layoutFormatter is implemented by the provided HelpFormatter implementations,
+// allowing their help text to be styled for the writer it is written to.
+type layoutFormatter interface {
+	layout() helpLayout
+}
+
+// helpLayout implements the help text layout shared by the provided
+// HelpFormatter implementations.
+type helpLayout struct {
+	rawDescription bool
+	rawText        bool
+	showDefaults   bool
+	theme          *Theme
+	catalog        Catalog
+}
+
+// format returns the complete help text for the provided parser, styled for
+// the provided writer.
+func (l helpLayout) format(p *Parser, w io.Writer) string {
+	// Get screen width to determine max line lengths later.
+	screenWidth := p.screenWidth()
+	l.theme = p.styleFor(w)
+	l.catalog = p.catalog()
+
+	notPositional, positional, commandStr := splitOptions(p)
+	parsers := visibleParsers(p.Parsers)
+	plugins := p.plugins()
+	var usage []string
+
+	longest := 0
+	for _, arg := range visibleOptions(p.Options) {
+		displayName := arg.DisplayName()
+		if displayWidth(displayName) > longest {
+			longest = displayWidth(displayName)
+		}
+	}
+	for _, subP := range parsers {
+		if displayWidth(join(", ", subP.names()...)) > longest {
+			longest = displayWidth(join(", ", subP.names()...))
+		}
+	}
+	for _, plugin := range plugins {
+		if displayWidth(plugin) > longest {
+			longest = displayWidth(plugin)
+		}
+	}
+
+	longest = longest + 4
+
+	usage = append(usage, l.usage(p, notPositional, positional, commandStr, screenWidth), "\n")
+
+	if len(p.UsageText) > 0 {
+		usage = append(usage, "\n", l.description(p.UsageText, screenWidth), "\n")
+	}
+
+	if len(parsers) > 0 {
+		var names []string
+		var help []string
+
+		for _, subP := range parsers {
+			names = append(names, join(", ", subP.names()...))
+			help = append(help, subP.HelpText)
+		}
+
+		usage = append(usage, "\n", l.theme.heading(message(l.catalog, MsgCommands, len(parsers))), "\n")
+		usage = append(usage, l.section(names, help, longest, screenWidth))
+	}
+
+	if len(plugins) > 0 {
+		help := make([]string, len(plugins))
+		usage = append(usage, "\n", l.theme.heading(message(l.catalog, MsgPlugins, len(plugins))), "\n")
+		usage = append(usage, l.section(plugins, help, longest, screenWidth))
+	}
+
+	if len(positional) > 0 {
+		var names []string
+		var help []string
+
+		for _, arg := range positional {
+			names = append(names, arg.GetUsage())
+			help = append(help, l.helpText(p, arg))
+		}
+
+		usage = append(usage, "\n", l.theme.heading(message(l.catalog, MsgPositionalArgs, len(positional))), "\n")
+		usage = append(usage, l.section(names, help, longest, screenWidth))
+	}
+
+	if len(notPositional) > 0 {
+		var names []string
+		var help []string
+
+		for _, arg := range notPositional {
+			names = append(names, arg.DisplayName())
+			help = append(help, l.helpText(p, arg))
+		}
+
+		usage = append(usage, "\n", l.theme.heading(message(l.catalog, MsgOptionalArgs, len(notPositional))), "\n")
+		usage = append(usage, l.section(names, help, longest, screenWidth))
+	}
+
+	if len(p.EpilogText) > 0 {
+		usage = append(usage, "\n", l.description(p.EpilogText, screenWidth))
+	}
+
+	return join("", usage...)
+}
+
+// splitOptions returns the parser's non-positional and positional options, along
+// with the representation of its commands, if any.
+func splitOptions(p *Parser) (notPositional, positional []*Option, commandStr string) {
+	for _, arg := range visibleOptions(p.Options) {
+		if !arg.IsPositional {
+			notPositional = append(notPositional, arg)
+		} else {
+			positional = append(positional, arg)
+		}
+	}
+
+	if len(visibleParsers(p.Parsers)) > 0 {
+		commandStr = commandList(p.Parsers)
+		if p.commandsOptional() {
+			commandStr = join("", "[", commandStr, "]")
+		}
+	}
+
+	return notPositional, positional, commandStr
+}
+
+// usage returns the usage line for the parser, wrapping option usages onto
+// new, indented lines when they would exceed the screen width.
+func (l helpLayout) usage(p *Parser, notPositional, positional []*Option, commandStr string, screenWidth int) string {
+	header := []string{message(l.catalog, MsgUsage, 1), p.ProgramName}
+	headerIndent := displayWidth(join(" ", header...))
+	headerLen := headerIndent
+
+	var notPosArgs []string
+	var posArgs []string
+
+	for _, arg := range notPositional {
+		argUsg := arg.GetUsage()
+		notPosArgs = append(notPosArgs, argUsg)
+		headerLen = headerLen + displayWidth(argUsg)
+		if headerLen+displayWidth(argUsg) > screenWidth {
+			headerLen = headerIndent
+			notPosArgs = append(notPosArgs, join("", "\n", spacer(headerIndent)))
+		}
+	}
+
+	if len(commandStr) > 0 {
+		posArgs = append(posArgs, commandStr)
+		headerLen = headerLen + displayWidth(commandStr)
+		if headerLen+displayWidth(commandStr) > screenWidth {
+			headerLen = headerIndent
+			posArgs = append(posArgs, join("", "\n", spacer(headerIndent)))
+		}
+	}
+
+	for _, arg := range positional {
+		argUsg := arg.GetUsage()
+		posArgs = append(posArgs, argUsg)
+		headerLen = headerLen + displayWidth(argUsg)
+		if headerLen+displayWidth(argUsg) > screenWidth {
+			headerLen = headerIndent
+			posArgs = append(posArgs, join("", "\n", spacer(headerIndent)))
+		}
+	}
+
+	header[0] = l.theme.heading(header[0])
+	header = append(header, notPosArgs...)
+	header = append(header, posArgs...)
+
+	return join(" ", header...)
+}
+
+// description returns the provided description or epilog text, wrapped to the
+// screen width unless raw descriptions are used.
+func (l helpLayout) description(text string, screenWidth int) string {
+	if l.rawDescription {
+		return text
+	}
+	return join("\n", wordWrap(join(" ", strings.Fields(text)...), screenWidth)...)
+}
+
+// helpText returns the help text to display for the provided option, followed
+// by a description of its constraints within the parser, if any.
+func (l helpLayout) helpText(p *Parser, opt *Option) string {
+	text := opt.HelpText
+	if constraints := p.constraintText(l.catalog, opt); len(constraints) > 0 && len(text) > 0 {
+		text = join(" ", text, constraints)
+	} else if len(constraints) > 0 {
+		text = constraints
+	}
+	if l.showDefaults && len(opt.DefaultVal) > 0 {
+		return join(" ", text, l.theme.defaultValue(join("", "(default: ", opt.DefaultVal, ")")))
+	}
+	return text
+}
+
+// helpLines breaks the provided help text into lines no longer than the
+// specified width, or by its own newlines when raw text is used.
+func (l helpLayout) helpLines(text string, width int) []string {
+	if l.rawText {
+		return strings.Split(text, "\n")
+	}
+	return wordWrap(join(" ", strings.Fields(text)...), width)
+}
+
+// section returns the rows of a help section, with each name followed by its
+// help text aligned into a column.
+func (l helpLayout) section(names, help []string, longest, screenWidth int) string {
+	var lines []string
+	for i, name := range names {
+		lines = append(lines, "  ", l.theme.option(name))
+		lines = append(lines, spacer(longest-displayWidth(name)-2))
+		if longest > screenWidth {
+			lines = append(lines, "\n", spacer(longest))
+		}
+
+		helpLines := l.helpLines(help[i], screenWidth-longest)
+		lines = append(lines, helpLines[0], "\n")
+		if len(helpLines) > 1 {
+			for _, helpLine := range helpLines[1:] {
+				lines = append(lines, spacer(longest), helpLine, "\n")
+			}
+		}
+	}
+
+	return join("", lines...)
+}
@@ -0,0 +1,117 @@
+package argparse
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestDefaultHelpFormatter tests to ensure the default formatter collapses
+// newlines within the description and help text.
+func TestDefaultHelpFormatter(t *testing.T) {
+	p := NewParser("first line\nsecond line", emptyNamespace()).Prog("prog").Formatter(DefaultHelpFormatter{})
+	p.AddOption(NewOption("o output", "output", "write output\nto a file").Nargs("1").Action(Store).Default("out.txt"))
+
+	help := p.GetHelp()
+
+	if !strings.Contains(help, "first line second line") {
+		t.Errorf("Expected the description to be collapsed onto one line:\n%s", help)
+	}
+	if !strings.Contains(help, "write output to a file\n") {
+		t.Errorf("Expected the help text to be collapsed onto one line:\n%s", help)
+	}
+	if strings.Contains(help, "default:") {
+		t.Errorf("Default values were not expected in the help text:\n%s", help)
+	}
+}
+
+// TestRawDescriptionHelpFormatter tests to ensure the raw description formatter
+// preserves newlines within the description only.
+func TestRawDescriptionHelpFormatter(t *testing.T) {
+	p := NewParser("first line\nsecond line", emptyNamespace()).Prog("prog").Formatter(RawDescriptionHelpFormatter{})
+	p.AddOption(NewOption("o output", "output", "write output\nto a file").Nargs("1").Action(Store).Default("out.txt"))
+
+	help := p.GetHelp()
+
+	if !strings.Contains(help, "first line\nsecond line") {
+		t.Errorf("Expected the description to be preserved:\n%s", help)
+	}
+	if !strings.Contains(help, "write output to a file\n") {
+		t.Errorf("Expected the help text to be collapsed onto one line:\n%s", help)
+	}
+}
+
+// TestRawTextHelpFormatter tests to ensure the raw text formatter preserves
+// newlines within both the description and help text.
+func TestRawTextHelpFormatter(t *testing.T) {
+	p := NewParser("first line\nsecond line", emptyNamespace()).Prog("prog").Formatter(RawTextHelpFormatter{})
+	p.AddOption(NewOption("o output", "output", "write output\nto a file").Nargs("1").Action(Store).Default("out.txt"))
+
+	help := p.GetHelp()
+
+	if !strings.Contains(help, "first line\nsecond line") {
+		t.Errorf("Expected the description to be preserved:\n%s", help)
+	}
+	if !strings.Contains(help, "write output\n") {
+		t.Errorf("Expected the help text newlines to be preserved:\n%s", help)
+	}
+}
+
+// TestArgumentDefaultsHelpFormatter tests to ensure the argument defaults
+// formatter appends default values to each option's help text.
+func TestArgumentDefaultsHelpFormatter(t *testing.T) {
+	p := NewParser("first line\nsecond line", emptyNamespace()).Prog("prog").Formatter(ArgumentDefaultsHelpFormatter{})
+	p.AddOption(NewOption("o output", "output", "write output\nto a file").Nargs("1").Action(Store).Default("out.txt"))
+
+	help := p.GetHelp()
+
+	if !strings.Contains(help, "write output to a file (default: out.txt)") {
+		t.Errorf("Expected the default value in the help text:\n%s", help)
+	}
+}
+
+type staticFormatter string
+
+func (f staticFormatter) FormatHelp(p *Parser) string { return string(f) }
+
+// TestParserFormatter tests to ensure a custom HelpFormatter is used to render
+// the parser's help text.
+func TestParserFormatter(t *testing.T) {
+	p := NewParser("", emptyNamespace()).Prog("prog").Formatter(staticFormatter("custom help"))
+
+	if p.GetHelp() != "custom help" {
+		t.Errorf("Expected the custom formatter to be used, but received: %s", p.GetHelp())
+	}
+}
+
+// TestHelpFormatter_DisplayWidth tests to ensure help text columns are aligned
+// by display width when option names contain wide characters.
+func TestHelpFormatter_DisplayWidth(t *testing.T) {
+	p := NewParser("", emptyNamespace()).Prog("prog").Width(80)
+	p.AddOption(NewFlag("名前", "name", "wide name"))
+	p.AddOption(NewFlag("abcd", "abcd", "narrow name"))
+
+	help := p.GetHelp()
+	if !strings.Contains(help, "  --名前  wide name\n") {
+		t.Errorf("Expected the wide option name to be aligned:\n%s", help)
+	}
+	if !strings.Contains(help, "  --abcd  narrow name\n") {
+		t.Errorf("Expected the narrow option name to be aligned:\n%s", help)
+	}
+}
+
+// TestHelpFormatter_Commands tests to ensure commands are listed within their
+// own section, along with their aliases and help text.
+func TestHelpFormatter_Commands(t *testing.T) {
+	p := NewParser("", emptyNamespace()).Prog("prog").Width(80)
+	p.AddParser("deploy", NewParser("", emptyNamespace()), "Deploy the application")
+	p.AddParserWithAliases("remove", []string{"rm"}, NewParser("", emptyNamespace()), "Remove the application")
+
+	help := p.GetHelp()
+	expected := "commands:\n  deploy      Deploy the application\n  remove, rm  Remove the application\n"
+	if !strings.Contains(help, expected) {
+		t.Errorf("Expected the commands section:\n%s\nbut received:\n%s", expected, help)
+	}
+	if !strings.HasPrefix(help, "usage: prog {deploy,remove,rm}") {
+		t.Errorf("Expected the commands within the usage line:\n%s", help)
+	}
+}
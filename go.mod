@@ -2,6 +2,4 @@ module github.com/clagraff/argparse
 
 go 1.19
 
-require github.com/nsf/termbox-go v1.1.1
-
-require github.com/mattn/go-runewidth v0.0.9 // indirect
+require github.com/mattn/go-runewidth v0.0.9
@@ -0,0 +1,85 @@
+package argparse
+
+// Middleware wraps the handler of a command, returning a handler which may run
+// code before or after calling the next handler.
+type Middleware func(next HandlerFunc) HandlerFunc
+
+// ParseHook is called before or after a parser processes its options. It
+// receives the parser, its namespace, and the arguments being parsed or left
+// unused. Returning an error stops parsing, and the error is reported as a
+// parsing error.
+type ParseHook func(p *Parser, ns *Namespace, args []string) error
+
+// Use appends the provided middleware to the parser. When executed, the handler
+// of the selected command is wrapped by the middleware of every parser in its
+// chain of commands, with the top-most parser's first middleware outermost.
+func (p *Parser) Use(middleware ...Middleware) *Parser {
+	p.HandlerMiddleware = append(p.HandlerMiddleware, middleware...)
+	return p
+}
+
+// PreParse appends hooks which are called before the parser processes its
+// options. Unlike post-parse hooks, a parser's hooks are called once, by the
+// parser itself, rather than for every command beneath it. A parent's hooks
+// receive every argument, including the command's name, and are called before
+// the command's hooks, which receive the arguments following its name.
+func (p *Parser) PreParse(hooks ...ParseHook) *Parser {
+	p.PreParseHooks = append(p.PreParseHooks, hooks...)
+	return p
+}
+
+// PostParse appends hooks which are called after all arguments have been parsed
+// successfully, before the callback or handler is called. The hooks of every
+// parser in the chain of commands are called, beginning with the top-most
+// parser, and receive the selected command's parser and merged namespace.
+func (p *Parser) PostParse(hooks ...ParseHook) *Parser {
+	p.PostParseHooks = append(p.PostParseHooks, hooks...)
+	return p
+}
+
+// chain returns the parser's chain of commands, beginning with its top-most
+// parent and ending with the parser itself.
+func (p *Parser) chain() []*Parser {
+	var parsers []*Parser
+	for parser := p; parser != nil; parser = parser.parent {
+		parsers = append([]*Parser{parser}, parsers...)
+	}
+	return parsers
+}
+
+// preParse calls the parser's own pre-parse hooks, returning the first error.
+func (p *Parser) preParse(args []string) error {
+	for _, hook := range p.PreParseHooks {
+		if err := hook(p, p.Namespace, args); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// postParse calls the post-parse hooks of the parser's chain of commands,
+// returning the first error.
+func (p *Parser) postParse(args []string) error {
+	for _, parser := range p.chain() {
+		for _, hook := range parser.PostParseHooks {
+			if err := hook(p, p.Namespace, args); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// wrap returns the provided handler wrapped by the middleware of the parser's
+// chain of commands.
+func (p *Parser) wrap(handler HandlerFunc) HandlerFunc {
+	var middleware []Middleware
+	for _, parser := range p.chain() {
+		middleware = append(middleware, parser.HandlerMiddleware...)
+	}
+
+	for i := len(middleware) - 1; i >= 0; i-- {
+		handler = middleware[i](handler)
+	}
+	return handler
+}
@@ -0,0 +1,88 @@
+package argparse
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// TestParserHooks tests to ensure pre- and post-parse hooks are called for
+// every parser in the chain of commands, and that their errors stop parsing.
+func TestParserHooks(t *testing.T) {
+	var calls []string
+	var err error
+	hook := func(name string) ParseHook {
+		return func(p *Parser, ns *Namespace, args []string) error {
+			calls = append(calls, name+":"+p.ProgramName)
+			return nil
+		}
+	}
+
+	preHook := func(name string) ParseHook {
+		return func(p *Parser, ns *Namespace, args []string) error {
+			calls = append(calls, name+":"+p.ProgramName+strings.Join(append([]string{""}, args...), ","))
+			return nil
+		}
+	}
+
+	deploy := NewParser("deploy", nil).Prog("deploy").PreParse(preHook("pre-deploy"))
+	p := NewParser("program", func(p *Parser, ns *Namespace, args []string, e error) {
+		calls, err = append(calls, "callback"), e
+	}).Prog("prog").PreParse(preHook("pre-prog")).PostParse(hook("post-prog"))
+	p.AddOption(NewFlag("v verbose", "verbose", "Verbose output"))
+	p.AddParser("deploy", deploy.PostParse(hook("post-deploy")))
+
+	p.Parse("deploy")
+	expected := "pre-prog:prog,deploy pre-deploy:deploy post-prog:deploy post-deploy:deploy callback"
+	if strings.Join(calls, " ") != expected {
+		t.Errorf("Expected the calls \"%s\", but received \"%s\"", expected, strings.Join(calls, " "))
+	}
+
+	// Each pre-parse hook is called once, with only the arguments of its parser.
+	calls = nil
+	p.Parse("-v", "deploy", "now")
+	expected = "pre-prog:prog,-v,deploy,now pre-deploy:deploy,now post-prog:deploy post-deploy:deploy callback"
+	if strings.Join(calls, " ") != expected {
+		t.Errorf("Expected the calls \"%s\", but received \"%s\"", expected, strings.Join(calls, " "))
+	}
+
+	errDenied := errors.New("denied")
+	calls = nil
+	p.PreParse(func(p *Parser, ns *Namespace, args []string) error { return errDenied })
+	p.Parse("deploy")
+	if err != errDenied {
+		t.Errorf("Expected the hook's error, but received: %v", err)
+	}
+	if strings.Join(calls, " ") != "pre-prog:prog,deploy callback" {
+		t.Errorf("Expected parsing to stop after the hook's error, but received \"%s\"", strings.Join(calls, " "))
+	}
+}
+
+// TestParserUse tests to ensure the handler is wrapped by the middleware of
+// every parser in the chain of commands, with the top-most parser's outermost.
+func TestParserUse(t *testing.T) {
+	var calls []string
+	middleware := func(name string) Middleware {
+		return func(next HandlerFunc) HandlerFunc {
+			return func(ctx context.Context, ns *Namespace) error {
+				calls = append(calls, name)
+				return next(ctx, ns)
+			}
+		}
+	}
+
+	deploy := NewParser("deploy", nil).Use(middleware("deploy")).Handler(func(ctx context.Context, ns *Namespace) error {
+		calls = append(calls, "handler")
+		return nil
+	})
+	p := NewParser("program", nil).Use(middleware("first"), middleware("second"))
+	p.AddParser("deploy", deploy)
+
+	if err := p.Execute(context.Background(), []string{"deploy"}); err != nil {
+		t.Fatalf("An unexpected error occurred: %v", err)
+	}
+	if strings.Join(calls, " ") != "first second deploy handler" {
+		t.Errorf("Expected the middleware in order, but received \"%s\"", strings.Join(calls, " "))
+	}
+}
@@ -0,0 +1,286 @@
+package argparse
+
+import (
+	"fmt"
+)
+
+// MessageKey identifies a message used within help text or error messages.
+type MessageKey string
+
+// Keys of the messages used within help text and error messages. Messages
+// containing formatting verbs are rendered using fmt.Sprintf, and translations
+// may use explicit argument indexes, such as `%[2]s`, to reorder arguments.
+const (
+	MsgUsage                MessageKey = "usage"                // "usage:"
+	MsgPositionalArgs       MessageKey = "positional-arguments" // "positional arguments:"
+	MsgOptionalArgs         MessageKey = "optional-arguments"   // "optional arguments:"
+	MsgCommands             MessageKey = "commands"             // "commands:"
+	MsgPlugins              MessageKey = "plugins"              // "plugins:"
+	MsgError                MessageKey = "error"                // "error:"
+	MsgWarning              MessageKey = "warning"              // "warning:"
+	MsgDeprecatedOption     MessageKey = "deprecated-option"    // option name
+	MsgDeprecatedCommand    MessageKey = "deprecated-command"   // command name
+	MsgInvalidChoice        MessageKey = "invalid-choice"       // option, choice, valid choices
+	MsgInvalidCommandName   MessageKey = "invalid-command-name" // command name
+	MsgInvalidFlagName      MessageKey = "invalid-flag-name"    // flag name
+	MsgInvalidOption        MessageKey = "invalid-option"       // option name
+	MsgInvalidType          MessageKey = "invalid-type"         // option, type, value
+	MsgMissingEnvVar        MessageKey = "missing-env-var"      // variable name
+	MsgTooFewArgs           MessageKey = "too-few-args"         // option
+	MsgMissingOneOrMoreArgs MessageKey = "missing-one-or-more"  // option
+	MsgMissingParser        MessageKey = "missing-command"      // available commands
+	MsgMissingOption        MessageKey = "missing-option"       // option name
+	MsgRequires             MessageKey = "requires"             // option, required option
+	MsgConflicts            MessageKey = "conflicts"            // option, conflicting option
+	MsgRequiredIf           MessageKey = "required-if"          // option, destination, value
+	MsgMissingOneOf         MessageKey = "missing-one-of"       // option names
+	MsgValidation           MessageKey = "validation"           // option, value, reason
+	MsgOutOfRange           MessageKey = "out-of-range"         // minimum, maximum
+	MsgNoMatch              MessageKey = "no-match"             // pattern
+	MsgTooShort             MessageKey = "too-short"            // length, plural by length
+	MsgTooLong              MessageKey = "too-long"             // length, plural by length
+	MsgNotOneOf             MessageKey = "not-one-of"           // choices
+	MsgNoSuchFile           MessageKey = "no-such-file"         // reason a path is invalid
+	MsgIsDir                MessageKey = "is-dir"               // reason a path is invalid
+	MsgNotDir               MessageKey = "not-dir"              // reason a path is invalid
+	MsgFileExists           MessageKey = "file-exists"          // reason a path is invalid
+	MsgPermissionDenied     MessageKey = "permission-denied"    // reason a path is invalid
+	MsgHelpRequires         MessageKey = "help-requires"        // required options
+	MsgHelpConflicts        MessageKey = "help-conflicts"       // conflicting options
+	MsgHelpRequiredIf       MessageKey = "help-required-if"     // destination, value
+	MsgHelpOneOf            MessageKey = "help-one-of"          // option names
+	MsgSuggestion           MessageKey = "suggestion"           // suggested names, plural by count
+)
+
+// Catalog provides the text of the messages used within help text and error
+// messages, allowing them to be translated. The count is used to select the
+// plural form of the message, where applicable.
+type Catalog interface {
+	Message(key MessageKey, count int) string
+}
+
+// Messages is a Catalog of messages, each having one or more plural forms.
+// Plural returns the index of the form to use for a count; when nil, the first
+// form is always used. Messages which are missing are taken from English.
+type Messages struct {
+	Plural func(count int) int
+	Text   map[MessageKey][]string
+}
+
+// Message returns the text of the message for the provided key, using the
+// plural form for the provided count.
+func (m Messages) Message(key MessageKey, count int) string {
+	forms := m.Text[key]
+	if len(forms) == 0 {
+		return ""
+	}
+
+	form := 0
+	if m.Plural != nil {
+		form = m.Plural(count)
+	}
+	if form < 0 {
+		form = 0
+	} else if form >= len(forms) {
+		form = len(forms) - 1
+	}
+	return forms[form]
+}
+
+// pluralOneOther selects the first form for a count of one, and the second form
+// otherwise, as is used by English and German.
+func pluralOneOther(count int) int {
+	if count == 1 {
+		return 0
+	}
+	return 1
+}
+
+// English contains the default English messages.
+var English = Messages{
+	Plural: pluralOneOther,
+	Text: map[MessageKey][]string{
+		MsgUsage:                {"usage:"},
+		MsgPositionalArgs:       {"positional arguments:"},
+		MsgOptionalArgs:         {"optional arguments:"},
+		MsgCommands:             {"commands:"},
+		MsgPlugins:              {"plugins:"},
+		MsgError:                {"error:"},
+		MsgWarning:              {"warning:"},
+		MsgDeprecatedOption:     {"option \"%s\" is deprecated"},
+		MsgDeprecatedCommand:    {"command \"%s\" is deprecated"},
+		MsgInvalidChoice:        {"%s: invalid choice \"%s\" (choose from: %s)"},
+		MsgInvalidCommandName:   {"invalid command name \"%s\""},
+		MsgInvalidFlagName:      {"invalid flag name \"%s\""},
+		MsgInvalidOption:        {"invalid option \"%s\""},
+		MsgInvalidType:          {"%s: invalid %s value: \"%s\""},
+		MsgMissingEnvVar:        {"missing environmental variable \"%s\""},
+		MsgTooFewArgs:           {"%s: too few arguments"},
+		MsgMissingOneOrMoreArgs: {"%s: at least one argument required"},
+		MsgMissingParser:        {"must use an available command: %s"},
+		MsgMissingOption:        {"option \"%s\" required"},
+		MsgRequires:             {"%s: requires %s"},
+		MsgConflicts:            {"%s: not allowed with %s"},
+		MsgRequiredIf:           {"option \"%s\" required when %s is \"%s\""},
+		MsgMissingOneOf:         {"one of %s required"},
+		MsgValidation:           {"%s: invalid value \"%s\": %s"},
+		MsgOutOfRange:           {"must be between %v and %v"},
+		MsgNoMatch:              {"must match %s"},
+		MsgTooShort:             {"must be at least %d character", "must be at least %d characters"},
+		MsgTooLong:              {"must be at most %d character", "must be at most %d characters"},
+		MsgNotOneOf:             {"must be %s", "must be one of %s"},
+		MsgNoSuchFile:           {"no such file or directory"},
+		MsgIsDir:                {"is a directory"},
+		MsgNotDir:               {"not a directory"},
+		MsgFileExists:           {"file already exists"},
+		MsgPermissionDenied:     {"permission denied"},
+		MsgHelpRequires:         {"requires %s"},
+		MsgHelpConflicts:        {"not allowed with %s"},
+		MsgHelpRequiredIf:       {"required when %s is \"%s\""},
+		MsgHelpOneOf:            {"one of %s required"},
+		MsgSuggestion:           {" (did you mean %s?)", " (did you mean one of %s?)"},
+	},
+}
+
+// German contains German translations of the messages.
+var German = Messages{
+	Plural: pluralOneOther,
+	Text: map[MessageKey][]string{
+		MsgUsage:                {"Aufruf:"},
+		MsgPositionalArgs:       {"Positionsargumente:"},
+		MsgOptionalArgs:         {"Optionale Argumente:"},
+		MsgCommands:             {"Befehle:"},
+		MsgPlugins:              {"Plugins:"},
+		MsgError:                {"Fehler:"},
+		MsgWarning:              {"Warnung:"},
+		MsgDeprecatedOption:     {"Option \"%s\" ist veraltet"},
+		MsgDeprecatedCommand:    {"Befehl \"%s\" ist veraltet"},
+		MsgInvalidChoice:        {"%s: ungültige Auswahl \"%s\" (möglich sind: %s)"},
+		MsgInvalidCommandName:   {"ungültiger Befehlsname \"%s\""},
+		MsgInvalidFlagName:      {"ungültiger Flag-Name \"%s\""},
+		MsgInvalidOption:        {"ungültige Option \"%s\""},
+		MsgInvalidType:          {"%s: ungültiger %s-Wert: \"%s\""},
+		MsgMissingEnvVar:        {"fehlende Umgebungsvariable \"%s\""},
+		MsgTooFewArgs:           {"%s: zu wenige Argumente"},
+		MsgMissingOneOrMoreArgs: {"%s: mindestens ein Argument erforderlich"},
+		MsgMissingParser:        {"einer der verfügbaren Befehle muss verwendet werden: %s"},
+		MsgMissingOption:        {"Option \"%s\" ist erforderlich"},
+		MsgRequires:             {"%s: erfordert %s"},
+		MsgConflicts:            {"%s: nicht zusammen mit %s erlaubt"},
+		MsgRequiredIf:           {"Option \"%s\" ist erforderlich, wenn %s \"%s\" ist"},
+		MsgMissingOneOf:         {"eine der Optionen %s ist erforderlich"},
+		MsgValidation:           {"%s: ungültiger Wert \"%s\": %s"},
+		MsgOutOfRange:           {"muss zwischen %v und %v liegen"},
+		MsgNoMatch:              {"muss %s entsprechen"},
+		MsgTooShort:             {"muss mindestens %d Zeichen lang sein"},
+		MsgTooLong:              {"darf höchstens %d Zeichen lang sein"},
+		MsgNotOneOf:             {"muss %s sein", "muss einer von %s sein"},
+		MsgNoSuchFile:           {"Datei oder Verzeichnis nicht gefunden"},
+		MsgIsDir:                {"ist ein Verzeichnis"},
+		MsgNotDir:               {"ist kein Verzeichnis"},
+		MsgFileExists:           {"Datei existiert bereits"},
+		MsgPermissionDenied:     {"Zugriff verweigert"},
+		MsgHelpRequires:         {"erfordert %s"},
+		MsgHelpConflicts:        {"nicht zusammen mit %s erlaubt"},
+		MsgHelpRequiredIf:       {"erforderlich, wenn %s \"%s\" ist"},
+		MsgHelpOneOf:            {"eine der Optionen %s ist erforderlich"},
+		MsgSuggestion:           {" (meinten Sie %s?)", " (meinten Sie eines von %s?)"},
+	},
+}
+
+// Japanese contains Japanese translations of the messages. Japanese does not
+// inflect for plurals, so each message has a single form.
+var Japanese = Messages{
+	Text: map[MessageKey][]string{
+		MsgUsage:                {"使い方:"},
+		MsgPositionalArgs:       {"位置引数:"},
+		MsgOptionalArgs:         {"オプション引数:"},
+		MsgCommands:             {"コマンド:"},
+		MsgPlugins:              {"プラグイン:"},
+		MsgError:                {"エラー:"},
+		MsgWarning:              {"警告:"},
+		MsgDeprecatedOption:     {"オプション \"%s\" は非推奨です"},
+		MsgDeprecatedCommand:    {"コマンド \"%s\" は非推奨です"},
+		MsgInvalidChoice:        {"%s: 無効な選択肢 \"%s\" (選択肢: %s)"},
+		MsgInvalidCommandName:   {"無効なコマンド名 \"%s\""},
+		MsgInvalidFlagName:      {"無効なフラグ名 \"%s\""},
+		MsgInvalidOption:        {"無効なオプション \"%s\""},
+		MsgInvalidType:          {"%s: %s として無効な値です: \"%s\""},
+		MsgMissingEnvVar:        {"環境変数 \"%s\" が見つかりません"},
+		MsgTooFewArgs:           {"%s: 引数が足りません"},
+		MsgMissingOneOrMoreArgs: {"%s: 少なくとも1つの引数が必要です"},
+		MsgMissingParser:        {"利用可能なコマンドを指定してください: %s"},
+		MsgMissingOption:        {"オプション \"%s\" は必須です"},
+		MsgRequires:             {"%s: %s が必要です"},
+		MsgConflicts:            {"%s: %s と同時に指定できません"},
+		MsgRequiredIf:           {"%[2]s が \"%[3]s\" の場合、オプション \"%[1]s\" は必須です"},
+		MsgMissingOneOf:         {"%s のいずれかが必要です"},
+		MsgValidation:           {"%s: 無効な値 \"%s\": %s"},
+		MsgOutOfRange:           {"%v から %v の範囲で指定してください"},
+		MsgNoMatch:              {"%s に一致する必要があります"},
+		MsgTooShort:             {"%d 文字以上で指定してください"},
+		MsgTooLong:              {"%d 文字以下で指定してください"},
+		MsgNotOneOf:             {"%s のいずれかを指定してください"},
+		MsgNoSuchFile:           {"ファイルまたはディレクトリが見つかりません"},
+		MsgIsDir:                {"ディレクトリです"},
+		MsgNotDir:               {"ディレクトリではありません"},
+		MsgFileExists:           {"ファイルが既に存在します"},
+		MsgPermissionDenied:     {"アクセスが拒否されました"},
+		MsgHelpRequires:         {"%s が必要"},
+		MsgHelpConflicts:        {"%s と同時に指定不可"},
+		MsgHelpRequiredIf:       {"%s が \"%s\" の場合は必須"},
+		MsgHelpOneOf:            {"%s のいずれかが必須"},
+		MsgSuggestion:           {" (%s のことですか?)"},
+	},
+}
+
+// localizedError is implemented by errors whose messages can be rendered using
+// a Catalog.
+type localizedError interface {
+	localize(c Catalog) string
+}
+
+// message returns the text of the message from the provided catalog, falling
+// back to English if the catalog is nil or does not contain the message.
+func message(c Catalog, key MessageKey, count int) string {
+	if c != nil {
+		if text := c.Message(key, count); len(text) > 0 {
+			return text
+		}
+	}
+	return English.Message(key, count)
+}
+
+// sprintMessage formats the message from the provided catalog using the
+// provided arguments.
+func sprintMessage(c Catalog, key MessageKey, count int, args ...interface{}) string {
+	return fmt.Sprintf(message(c, key, count), args...)
+}
+
+// Locale sets the catalog used for the messages within the parser's help text
+// and error messages. The English, German, and Japanese catalogs are provided.
+// Sub-parsers without their own catalog will use their parent's catalog.
+func (p *Parser) Locale(catalog Catalog) *Parser {
+	p.MessageCatalog = catalog
+	return p
+}
+
+// catalog returns the catalog to be used for the parser's messages, or nil if
+// the default English messages should be used.
+func (p *Parser) catalog() Catalog {
+	for parser := p; parser != nil; parser = parser.parent {
+		if parser.MessageCatalog != nil {
+			return parser.MessageCatalog
+		}
+	}
+	return nil
+}
+
+// localize returns the message of the provided error using the parser's
+// catalog.
+func (p *Parser) localize(err error) string {
+	if e, ok := err.(localizedError); ok {
+		return e.localize(p.catalog())
+	}
+	return err.Error()
+}
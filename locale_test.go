@@ -0,0 +1,92 @@
+package argparse
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// TestParserLocale tests to ensure help text headings are rendered using the
+// parser's catalog, and are inherited by sub-parsers.
+func TestParserLocale(t *testing.T) {
+	child := NewParser("", emptyNamespace()).Prog("child")
+	child.AddOption(NewArg("name", "name", "Name"))
+	child.AddOption(NewFlag("v verbose", "verbose", "Verbose"))
+
+	p := NewParser("", emptyNamespace()).Prog("prog").Locale(German)
+	p.AddParser("child", child)
+
+	help := child.GetHelp()
+	for _, expected := range []string{"Aufruf: child", "Positionsargumente:", "Optionale Argumente:"} {
+		if !strings.Contains(help, expected) {
+			t.Errorf("Expected \"%s\" within the help text:\n%s", expected, help)
+		}
+	}
+
+	if help := p.GetHelp(); !strings.Contains(help, "Befehle:\n  child") {
+		t.Errorf("Expected the translated commands heading:\n%s", help)
+	}
+}
+
+// TestParserLocale_Errors tests to ensure errors are displayed using the
+// parser's catalog, while Error continues to return English messages.
+func TestParserLocale_Errors(t *testing.T) {
+	var errOut bytes.Buffer
+	var err error
+	p := NewParser("", func(p *Parser, ns *Namespace, args []string, e error) {
+		err = e
+	}).Prog("prog").ErrOutput(&errOut).Locale(Japanese)
+	p.AddOption(NewFlag("verbose", "verbose", "Verbose"))
+
+	p.Parse("--verbos")
+	p.ShowError(err)
+
+	expected := "エラー: 無効なオプション \"verbos\" (\"--verbose\" のことですか?)"
+	if !strings.HasPrefix(errOut.String(), expected) {
+		t.Errorf("Expected the error output to begin with:\n%s\nbut received:\n%s", expected, errOut.String())
+	}
+	if !strings.Contains(errOut.String(), "使い方: prog") {
+		t.Errorf("Expected the translated usage within the error output:\n%s", errOut.String())
+	}
+	if err.Error() != "invalid option \"verbos\" (did you mean \"--verbose\"?)" {
+		t.Errorf("Expected an English error message, but received: %s", err.Error())
+	}
+}
+
+// TestMessages_Plural tests to ensure the plural form of a message is selected
+// using the provided count.
+func TestMessages_Plural(t *testing.T) {
+	one := InvalidOptionErr{Name: "x", Suggestions: []string{"-a"}}
+	many := InvalidOptionErr{Name: "x", Suggestions: []string{"-a", "-b"}}
+
+	tests := []struct {
+		err      localizedError
+		catalog  Catalog
+		expected string
+	}{
+		{one, English, "invalid option \"x\" (did you mean \"-a\"?)"},
+		{many, English, "invalid option \"x\" (did you mean one of \"-a\", \"-b\"?)"},
+		{one, German, "ungültige Option \"x\" (meinten Sie \"-a\"?)"},
+		{many, German, "ungültige Option \"x\" (meinten Sie eines von \"-a\", \"-b\"?)"},
+		{many, Japanese, "無効なオプション \"x\" (\"-a\", \"-b\" のことですか?)"},
+	}
+
+	for _, test := range tests {
+		if msg := test.err.localize(test.catalog); msg != test.expected {
+			t.Errorf("Expected the message \"%s\", but received \"%s\"", test.expected, msg)
+		}
+	}
+}
+
+// TestMessages_Fallback tests to ensure messages missing from a catalog are
+// taken from the English catalog.
+func TestMessages_Fallback(t *testing.T) {
+	catalog := Messages{Text: map[MessageKey][]string{MsgUsage: {"uso:"}}}
+
+	if msg := message(catalog, MsgUsage, 1); msg != "uso:" {
+		t.Errorf("Expected the catalog's message, but received: %s", msg)
+	}
+	if msg := message(catalog, MsgError, 1); msg != "error:" {
+		t.Errorf("Expected the English message, but received: %s", msg)
+	}
+}
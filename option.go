@@ -35,38 +35,51 @@ func ValidateChoice(f Option, arg string) error {
 		}
 	}
 
-	return InvalidChoiceErr{f, arg}
+	return InvalidChoiceErr{Opt: f, Arg: arg}
 }
 
 // ValidateType attempt to type-convert the string argument to the flag's desired
 // type. It will return an error if the provided interface value does not
 // satisfy the Option's expected Reflect.Kind type.
 func ValidateType(f Option, arg string) error {
+	var err error
+
 	switch f.ExpectedType {
 	case reflect.Invalid, reflect.String:
 		return nil
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		if _, err := strconv.Atoi(arg); err == nil {
+		if _, err = strconv.Atoi(arg); err == nil {
 			return nil
 		}
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-		if _, err := strconv.ParseUint(arg, 10, 0); err == nil {
+		if _, err = strconv.ParseUint(arg, 10, 0); err == nil {
 			return nil
 		}
 	case reflect.Float32:
-		if _, err := strconv.ParseFloat(arg, 32); err == nil {
+		if _, err = strconv.ParseFloat(arg, 32); err == nil {
 			return nil
 		}
 	case reflect.Float64:
-		if _, err := strconv.ParseFloat(arg, 64); err == nil {
+		if _, err = strconv.ParseFloat(arg, 64); err == nil {
 			return nil
 		}
 	case reflect.Bool:
-		if _, err := strconv.ParseBool(arg); err == nil {
+		if _, err = strconv.ParseBool(arg); err == nil {
 			return nil
 		}
 	}
-	return InvalidTypeErr{f, arg}
+	return InvalidTypeErr{Opt: f, Arg: arg, Err: err}
+}
+
+// ValidateValue returns a ValidationErr wrapping the error of the first of the
+// flag's validators which the provided argument does not satisfy.
+func ValidateValue(f Option, arg string) error {
+	for _, validator := range f.Validators {
+		if err := validator(arg); err != nil {
+			return ValidationErr{Opt: f, Arg: arg, Err: err}
+		}
+	}
+	return nil
 }
 
 // NewOption instantiates a new Option pointer, initializing it as a boolean
@@ -100,18 +113,28 @@ func NewOption(names, dest, help string) *Option {
 //	f := argparse.NewFlag("-n --dry", "dryRun", "Enable dry-run mode")
 //	a := argparse.NewArg("--in", "inputPath", "Path to specified input file")
 type Option struct {
-	ArgNum        string       // Any digit, "+", "?", "*", or "r" and "R" to represent how many arguments an option can expect.
-	ConstVal      string       // A constant value to represent when used with the actions.StoreConst action.
-	DefaultVal    string       // A value to represent the Option by default.
-	DesiredAction Action       // A callback function which will parse an option and its arguments.
-	DestName      string       // A unique identifier to store an option's value within a namespace.
-	ExpectedType  reflect.Kind // The variable-type that an Option's arguments are to be interpretted as.
-	HelpText      string       // Text describing the usage/meaning of the Option.
-	IsRequired    bool         // Indicate if an Option must be present when parsing.
-	IsPositional  bool         // Indicate that an Option is identified by its position when parsing.
-	MetaVarText   []string     // Text used when representing an Option and its arguments.
-	PublicNames   []string     // Qualifiers for identifying the option during parsing.
-	ValidChoices  []string     // A slice of valid choices for arguments of the Option.
+	ArgNum             string       // Any digit, "+", "?", "*", or "r" and "R" to represent how many arguments an option can expect.
+	ConflictNames      []string     // Public names of options which cannot be provided along with the Option.
+	ConstVal           string       // A constant value to represent when used with the actions.StoreConst action.
+	DefaultVal         string       // A value to represent the Option by default.
+	DeprecationText    string       // Text explaining what to use instead of a deprecated Option.
+	DesiredAction      Action       // A callback function which will parse an option and its arguments.
+	DestName           string       // A unique identifier to store an option's value within a namespace.
+	ExpectedType       reflect.Kind // The variable-type that an Option's arguments are to be interpretted as.
+	FileFlag           int          // Flags, such as os.O_RDONLY, used when opening the Option's arguments as files.
+	HelpText           string       // Text describing the usage/meaning of the Option.
+	IsDeprecated       bool         // Indicate that a warning is displayed when the Option is used.
+	IsHidden           bool         // Indicate that an Option is excluded from help text and documentation.
+	IsPersistent       bool         // Indicate that an Option is inherited by sub-parsers.
+	IsRequired         bool         // Indicate if an Option must be present when parsing.
+	IsPositional       bool         // Indicate that an Option is identified by its position when parsing.
+	MetaVarText        []string     // Text used when representing an Option and its arguments.
+	OpensFile          bool         // Indicate that the Option's arguments are opened as files once parsed.
+	PublicNames        []string     // Qualifiers for identifying the option during parsing.
+	RequiredConditions []Condition  // Conditions under which the Option must be present when parsing.
+	RequiredNames      []string     // Public names of options which must be provided along with the Option.
+	ValidChoices       []string     // A slice of valid choices for arguments of the Option.
+	Validators         []Validator  // Functions which each argument of the Option must satisfy.
 }
 
 // Action sets the option's action to the provided action function.
@@ -147,6 +170,15 @@ func (f *Option) Dest(name string) *Option {
 	return f
 }
 
+// Deprecated marks the option as deprecated. The option can still be used, but
+// a warning containing the provided message, such as "use --new instead", is
+// written to the parser's error writer when it is.
+func (f *Option) Deprecated(msg string) *Option {
+	f.IsDeprecated = true
+	f.DeprecationText = msg
+	return f
+}
+
 // DisplayName returns the option's public name, prefixed with the appropriate number
 // of hyphen-minus characters.
 func (f *Option) DisplayName() string {
@@ -299,6 +331,13 @@ func (f *Option) Help(text string) *Option {
 	return f
 }
 
+// Hidden excludes the option from the parser's help text, usage, and
+// documentation, while still allowing it to be used.
+func (f *Option) Hidden() *Option {
+	f.IsHidden = true
+	return f
+}
+
 // IsPublicName will check the provided string against current option's
 // public names to determine if there is a match.
 func (f *Option) IsPublicName(name string) bool {
@@ -368,6 +407,14 @@ func (f *Option) NotPositional() *Option {
 	return f
 }
 
+// Persistent enables a non-positional option to be used after the name of any
+// command beneath the parser it was added to. Its value is stored within the
+// namespace of the command being parsed.
+func (f *Option) Persistent() *Option {
+	f.IsPersistent = true
+	return f
+}
+
 // Positional enables a option to be positionally interpretted.
 func (f *Option) Positional() *Option {
 	f.IsPositional = true
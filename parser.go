@@ -2,28 +2,75 @@ package argparse
 
 import (
 	"fmt"
+	"io"
 	"os"
+	"strconv"
 	"strings"
 )
 
-// SubParser contains a Parser pointer and the public name for the sub command.
+// SubParser contains a Parser pointer and the public name for the sub command,
+// along with any alternative names which can be used instead, and a short
+// summary of the sub command.
 type SubParser struct {
-	Parser *Parser
-	Name   string
+	Parser   *Parser
+	Name     string
+	Aliases  []string
+	HelpText string
+}
+
+// names returns the sub command's public name followed by its aliases.
+func (s SubParser) names() []string {
+	return append([]string{s.Name}, s.Aliases...)
+}
+
+// commandList returns the names and aliases of the provided sub commands in the
+// form of `{name,alias,...}`, excluding hidden sub commands.
+func commandList(parsers []SubParser) string {
+	var names []string
+	for _, subP := range visibleParsers(parsers) {
+		names = append(names, subP.names()...)
+	}
+	return join("", "{", join(",", names...), "}")
 }
 
 // Parser contains program-level settings and information, stores options,
 // and values collected upon parsing.
 type Parser struct {
-	AllowAbbrev bool
-	Callback    func(*Parser, *Namespace, []string, error)
-	EpilogText  string
-	Namespace   *Namespace
-	Options     []*Option
-	Parsers     []SubParser
-	ProgramName string
-	UsageText   string
-	VersionDesc string
+	AllowAbbrev        bool
+	Callback           func(*Parser, *Namespace, []string, error)
+	CollectAllErrors   bool
+	CommandDest        string
+	CommandHandler     HandlerFunc
+	CommandPrefixes    bool
+	DefaultCommandName string
+	DeprecationText    string
+	ColorTheme         *Theme
+	EpilogText         string
+	ErrorCodes         []ErrorCode
+	ErrWriter          io.Writer
+	ExitOnErr          bool
+	HandlerMiddleware  []Middleware
+	HelpFormatter      HelpFormatter
+	IsDeprecated       bool
+	IsHidden           bool
+	MessageCatalog     Catalog
+	Namespace          *Namespace
+	OneOfGroups        [][]*Option
+	Options            []*Option
+	OptionalCommands   bool
+	OutWriter          io.Writer
+	Parsers            []SubParser
+	PluginPaths        []string
+	PluginsEnabled     bool
+	PostParseHooks     []ParseHook
+	PreParseHooks      []ParseHook
+	ProgramName        string
+	ScreenWidth        int
+	UsageText          string
+	VersionDesc        string
+
+	openedFiles []*os.File
+	parent      *Parser
 }
 
 // AddHelp adds a new option to output usage information for the current parser
@@ -35,6 +82,18 @@ func (p *Parser) AddHelp() *Parser {
 	return p
 }
 
+// AddHelpCommand adds a new "help" command, which outputs the help text of the
+// command named by its arguments, such as `prog help cluster scale`. Without
+// arguments, the help text of the current parser is output.
+func (p *Parser) AddHelpCommand() *Parser {
+	// The command's arguments are stored under a destination of their own, so
+	// that they do not replace the command path stored by AddSubparsers.
+	helpParser := NewParser("Show help for a command", nil)
+	helpParser.AddOption(NewOption("command", "help-command", "Command to show help for").Nargs("*").Positional().Action(ShowCommandHelp))
+
+	return p.AddParser("help", helpParser, "Show help for a command")
+}
+
 // AddVersion adds a new option to the program version.
 func (p *Parser) AddVersion() *Parser {
 	versionOption := NewOption("v version", "version", "Show program version").Action(ShowVersion)
@@ -56,200 +115,361 @@ func (p *Parser) AddOptions(opts ...*Option) *Parser {
 }
 
 // AddParser appends the provided parse to the current parser as an available command.
-func (p *Parser) AddParser(name string, parser *Parser) *Parser {
+// An optional one-line summary of the command can be provided, which is shown
+// alongside the command's name within the parser's help text.
+func (p *Parser) AddParser(name string, parser *Parser, help ...string) *Parser {
+	return p.AddParserWithAliases(name, nil, parser, help...)
+}
+
+// AddParserWithAliases appends the provided parser to the current parser as an
+// available command, which can be used by its name or any of its aliases. An
+// optional one-line summary of the command can be provided, as with AddParser.
+func (p *Parser) AddParserWithAliases(name string, aliases []string, parser *Parser, help ...string) *Parser {
 	if p.Parsers == nil {
 		p.Parsers = make([]SubParser, 0)
 	}
-	p.Parsers = append(p.Parsers, SubParser{Name: name, Parser: parser})
+	subP := SubParser{Name: name, Aliases: aliases, HelpText: join(" ", help...), Parser: parser}
+	p.Parsers = append(p.Parsers, subP)
+	parser.parent = p
 	return p
 }
 
-// GetOption retrieves the first option with a public name matching the specified
-// name, or will otherwise return an error.
-func (p *Parser) GetOption(name string) (*Option, error) {
-	if len(name) <= 0 {
-		return nil, InvalidFlagNameErr{name}
+// AddSubparsers sets the destination within the namespace where the path of
+// commands selected while parsing is stored, such as `[]string{"cluster",
+// "scale"}`. Each command is recorded by its name, rather than any alias used.
+// Commands without a callback of their own use their parent's callback, so that
+// a single callback receiving the merged namespace can route to each command.
+// Sub-parsers record their commands within their parent's destination, unless
+// given their own.
+func (p *Parser) AddSubparsers(dest string) *Parser {
+	p.CommandDest = dest
+	return p
+}
+
+// commandPath returns the program name of the root parser, followed by the
+// name of each command leading to the parser, such as `[]string{"prog",
+// "cluster", "scale"}`.
+func (p *Parser) commandPath() []string {
+	if p.parent == nil {
+		return []string{p.ProgramName}
 	}
-	for _, option := range p.Options {
-		if option.IsPublicName(name) {
-			return option, nil
+
+	path := p.parent.commandPath()
+	for _, subP := range p.parent.Parsers {
+		if subP.Parser == p {
+			return append(path, subP.Name)
+		}
+	}
+	return path
+}
+
+// commandDest returns the namespace destination of the command path, or an
+// empty string if the path is not recorded.
+func (p *Parser) commandDest() string {
+	for parser := p; parser != nil; parser = parser.parent {
+		if len(parser.CommandDest) > 0 {
+			return parser.CommandDest
 		}
 	}
+	return ""
+}
 
-	return nil, InvalidFlagNameErr{name}
+// DefaultCommand sets the name of the command to be used when no command is
+// provided. The default command is parsed using no arguments, while any options
+// provided are parsed by the parser itself, such as `prog --verbose`.
+func (p *Parser) DefaultCommand(name string) *Parser {
+	p.DefaultCommandName = name
+	return p
 }
 
-// GetParser retrieves the desired sub-parser from the current parser, or returns
-// an error if the desired parser does not exist.
-func (p Parser) GetParser(name string) (*Parser, error) {
-	if len(name) <= 0 {
-		return nil, InvalidParserNameErr{name}
+// Hidden excludes the parser's command from its parent's help text, usage, and
+// documentation, while still allowing it to be used.
+func (p *Parser) Hidden() *Parser {
+	p.IsHidden = true
+	return p
+}
+
+// Deprecated marks the parser's command as deprecated. The command can still be
+// used, but a warning containing the provided message, such as "use deploy
+// instead", is written to the error writer when it is.
+func (p *Parser) Deprecated(msg string) *Parser {
+	p.IsDeprecated = true
+	p.DeprecationText = msg
+	return p
+}
+
+// CommandsOptional allows no command to be provided, rather than resulting in a
+// MissingParserErr. The parser's own callback or handler is then used.
+func (p *Parser) CommandsOptional() *Parser {
+	p.OptionalCommands = true
+	return p
+}
+
+// commandsOptional returns true if the parser can be used without providing a
+// command.
+func (p *Parser) commandsOptional() bool {
+	return p.OptionalCommands || len(p.DefaultCommandName) > 0
+}
+
+// AllowCommandPrefixes sets whether commands can be used by a unique prefix of
+// their name or aliases, such as "dep" for "deploy". Sub-parsers allow prefixes
+// if any of their parents do.
+func (p *Parser) AllowCommandPrefixes(allow bool) *Parser {
+	p.CommandPrefixes = allow
+	return p
+}
+
+// commandPrefixes returns true if the parser, or any of its parents, allows
+// commands to be used by a unique prefix.
+func (p *Parser) commandPrefixes() bool {
+	for parser := p; parser != nil; parser = parser.parent {
+		if parser.CommandPrefixes {
+			return true
+		}
 	}
+	return false
+}
 
+// matchParser returns the sub command with a name or alias matching the provided
+// name. When prefixes are allowed, a sub command is also matched if the name is
+// a prefix of only its names. If the prefix is ambiguous, the names of each
+// candidate sub command are returned instead.
+func (p *Parser) matchParser(name string) (SubParser, []string, bool) {
 	for _, subP := range p.Parsers {
-		if subP.Name == name {
-			return subP.Parser, nil
+		for _, subName := range subP.names() {
+			if subName == name {
+				return subP, nil, true
+			}
 		}
 	}
 
-	return nil, InvalidParserNameErr{name}
-}
+	if !p.commandPrefixes() || len(name) == 0 {
+		return SubParser{}, nil, false
+	}
 
-// GetHelp returns a string containing the parser's description text,
-// and the usage information for each option currently incorporated within
-// the parser.
-func (p *Parser) GetHelp() string {
-	// Get screen width to determine max line lengths later.
-	screenWidth := getScreenWidth()
-
-	var commands []string
-	var commandStr string
-	var notPositional []*Option
-	var positional []*Option
-	var usage []string
-
-	header := []string{"usage:", p.ProgramName}
-	headerIndent := len(join(" ", header...))
-	headerLen := headerIndent
-
-	var notPosArgs []string
-	var posArgs []string
-	longest := 0
-
-	for _, arg := range p.Options {
-		if !arg.IsPositional {
-			notPositional = append(notPositional, arg)
-		} else {
-			positional = append(positional, arg)
+	// Hidden commands only match their exact name or alias.
+	var matches []SubParser
+	var candidates []string
+	for _, subP := range visibleParsers(p.Parsers) {
+		for _, subName := range subP.names() {
+			if strings.HasPrefix(subName, name) {
+				matches = append(matches, subP)
+				candidates = append(candidates, subP.Name)
+				break
+			}
 		}
 	}
 
-	for _, arg := range notPositional {
-		displayName := arg.DisplayName()
-		if len(displayName) > longest {
-			longest = len(displayName)
+	if len(matches) == 1 {
+		return matches[0], nil, true
+	}
+	return SubParser{}, candidates, false
+}
+
+// GetOption retrieves the first option with a public name matching the specified
+// name, or will otherwise return an error.
+func (p *Parser) GetOption(name string) (*Option, error) {
+	if len(name) <= 0 {
+		return nil, InvalidFlagNameErr{Name: name}
+	}
+	for _, option := range p.Options {
+		if option.IsPublicName(name) {
+			return option, nil
 		}
+	}
 
-		argUsg := arg.GetUsage()
-		notPosArgs = append(notPosArgs, arg.GetUsage())
-		headerLen = headerLen + len(argUsg)
-		if headerLen+len(argUsg) > screenWidth {
-			headerLen = headerIndent
-			notPosArgs = append(notPosArgs, join("", "\n", spacer(headerIndent)))
+	return nil, InvalidFlagNameErr{Name: name}
+}
+
+// persistentOptions returns the persistent options of the parser's parents,
+// beginning with its immediate parent.
+func (p *Parser) persistentOptions() []*Option {
+	var options []*Option
+	for parser := p.parent; parser != nil; parser = parser.parent {
+		for _, option := range parser.Options {
+			if option.IsPersistent && !option.IsPositional {
+				options = append(options, option)
+			}
 		}
 	}
+	return options
+}
 
-	if len(p.Parsers) > 0 {
-		for _, subP := range p.Parsers {
-			commands = append(commands, subP.Name)
+// findOption returns the non-positional option with a public name matching the
+// provided name, searching the parser's options before the persistent options
+// of its parents. Nil is returned if no option matches.
+func (p *Parser) findOption(name string) *Option {
+	for _, option := range p.Options {
+		if !option.IsPositional && option.IsPublicName(name) {
+			return option
 		}
-		commandStr = join("", "{", join(",", commands...), "}")
-		if len(commandStr) > longest {
-			longest = len(commandStr)
+	}
+	for _, option := range p.persistentOptions() {
+		if option.IsPublicName(name) {
+			return option
 		}
+	}
+	return nil
+}
 
-		posArgs = append(posArgs, commandStr)
-		headerLen = headerLen + len(commandStr)
-		if headerLen+len(commandStr) > screenWidth {
-			headerLen = headerIndent
-			posArgs = append(posArgs, join("", "\n", spacer(headerIndent)))
+// suggestOptions returns the display names of visible, non-positional options
+// with public names similar to the provided name.
+func (p *Parser) suggestOptions(name string) []string {
+	var names []string
+	for _, option := range visibleOptions(append(p.persistentOptions(), p.Options...)) {
+		if !option.IsPositional {
+			names = append(names, option.PublicNames...)
 		}
 	}
 
-	for _, arg := range positional {
-		displayName := arg.DisplayName()
-		if len(displayName) > longest {
-			longest = len(displayName)
+	var suggestions []string
+	for _, suggestion := range suggest(name, names...) {
+		suggestions = append(suggestions, optionName(suggestion))
+	}
+	return suggestions
+}
+
+// optionName returns the provided public name of an option as it is used,
+// prefixed by "-" for single letter names, or "--" otherwise.
+func optionName(name string) string {
+	if len(name) == 1 {
+		return join("", "-", name)
+	}
+	return join("", "--", name)
+}
+
+// visibleOptions returns the provided options, excluding hidden options.
+func visibleOptions(options []*Option) []*Option {
+	var visible []*Option
+	for _, option := range options {
+		if !option.IsHidden {
+			visible = append(visible, option)
 		}
+	}
+	return visible
+}
 
-		argUsg := arg.GetUsage()
-		posArgs = append(posArgs, arg.GetUsage())
-		headerLen = headerLen + len(argUsg)
-		if headerLen+len(argUsg) > screenWidth {
-			headerLen = headerIndent
-			posArgs = append(posArgs, join("", "\n", spacer(headerIndent)))
+// visibleParsers returns the provided sub commands, excluding hidden sub
+// commands.
+func visibleParsers(parsers []SubParser) []SubParser {
+	var visible []SubParser
+	for _, subP := range parsers {
+		if !subP.Parser.IsHidden {
+			visible = append(visible, subP)
 		}
 	}
+	return visible
+}
 
-	longest = longest + 4
+// warnDeprecated writes a warning to the parser's error writer, stating that
+// the named option or command is deprecated, followed by the provided message.
+func (p *Parser) warnDeprecated(key MessageKey, name, msg string) {
+	w := p.errWriter()
+	catalog := p.catalog()
 
-	header = append(header, notPosArgs...)
-	header = append(header, posArgs...)
+	warning := sprintMessage(catalog, key, 1, name)
+	if len(msg) > 0 {
+		warning = join(": ", warning, msg)
+	}
 
-	usage = append(usage, join(" ", header...), "\n")
+	prefix := p.styleFor(w).errorPrefix(message(catalog, MsgWarning, 1))
+	fmt.Fprintln(w, join(" ", p.root().ProgramName+":", prefix, warning))
+}
 
-	if len(p.UsageText) > 0 {
-		usage = append(usage, "\n", p.UsageText, "\n")
+// suggestCommands returns the names of visible commands and plugins similar to
+// the provided name.
+func (p *Parser) suggestCommands(name string) []string {
+	var names []string
+	for _, subP := range visibleParsers(p.Parsers) {
+		names = append(names, subP.names()...)
 	}
+	names = append(names, p.plugins()...)
+	return suggest(name, names...)
+}
 
-	if len(positional) > 0 || len(commandStr) > 0 {
-		usage = append(usage, "\n", "positional arguments:", "\n")
-		var names []string
-		var help []string
-
-		if len(commandStr) > 0 {
-			names = append(names, commandStr)
-			help = append(help, "commands")
+// commandIndex returns the index of the first argument which is neither an
+// option nor an argument of an option, and so names the command to be used.
+// Options expecting a variable number of arguments stop consuming arguments at
+// the name of a command. The length of the arguments is returned if no command
+// is found.
+func (p *Parser) commandIndex(args []string) int {
+	tokens := tokenize(args...)
+	for i := 0; i < len(tokens); i++ {
+		if !tokens[i].IsOption {
+			return tokens[i].Index
 		}
 
-		for _, arg := range positional {
-			names = append(names, arg.GetUsage())
-			help = append(help, arg.HelpText)
+		option := p.findOption(tokens[i].Value)
+		if option == nil {
+			continue
 		}
 
-		var lines []string
-		for i, name := range names {
-			lines = append(lines, "  ", name)
-			lines = append(lines, spacer(longest-len(name)-2))
-			if longest > screenWidth {
-				lines = append(lines, "\n", spacer(longest))
+		max, err := strconv.Atoi(option.ArgNum)
+		variable := err != nil
+		if variable {
+			max = len(tokens)
+			if option.ArgNum == "?" {
+				max = 1
 			}
+		}
 
-			helpLines := wordWrap(help[i], screenWidth-longest)
-			lines = append(lines, helpLines[0], "\n")
-			if len(helpLines) > 1 {
-				for _, helpLine := range helpLines[1:] {
-					lines = append(lines, spacer(longest), helpLine, "\n")
-				}
+		for ; max > 0 && i+1 < len(tokens) && !tokens[i+1].IsOption; max-- {
+			if _, _, ok := p.matchParser(tokens[i+1].Value); variable && ok {
+				break
 			}
+			i++
 		}
-		usage = append(usage, lines...)
 	}
 
-	if len(notPositional) > 0 {
-		usage = append(usage, "\n", "optional arguments:", "\n")
-		var names []string
-		var help []string
-
-		for _, arg := range notPositional {
-			names = append(names, arg.DisplayName())
-			help = append(help, arg.HelpText)
-		}
+	return len(args)
+}
 
-		var lines []string
-		for i, name := range names {
-			lines = append(lines, "  ", name)
-			lines = append(lines, spacer(longest-len(name)-2))
-			if longest > screenWidth {
-				lines = append(lines, "\n", spacer(longest))
-			}
+// GetParser retrieves the desired sub-parser from the current parser, or returns
+// an error if the desired parser does not exist.
+func (p Parser) GetParser(name string) (*Parser, error) {
+	if len(name) <= 0 {
+		return nil, InvalidParserNameErr{Name: name}
+	}
 
-			helpLines := wordWrap(help[i], screenWidth-longest)
-			lines = append(lines, helpLines[0], "\n")
-			if len(helpLines) > 1 {
-				for _, helpLine := range helpLines[1:] {
-					lines = append(lines, spacer(longest), helpLine, "\n")
-				}
-			}
-		}
-		usage = append(usage, lines...)
+	if subP, _, ok := p.matchParser(name); ok {
+		return subP.Parser, nil
 	}
 
-	if len(p.EpilogText) > 0 {
-		usage = append(usage, "\n", p.EpilogText)
+	return nil, InvalidParserNameErr{Name: name}
+}
+
+// GetHelp returns a string containing the parser's description text,
+// and the usage information for each option currently incorporated within
+// the parser. The text is rendered by the parser's HelpFormatter, or by the
+// DefaultHelpFormatter if none has been set.
+func (p *Parser) GetHelp() string {
+	return p.helpFor(p.outWriter())
+}
+
+// helpFor returns the parser's help text, styled for the provided writer when
+// using one of the provided formatters.
+func (p *Parser) helpFor(w io.Writer) string {
+	var formatter HelpFormatter = DefaultHelpFormatter{}
+	if p.HelpFormatter != nil {
+		formatter = p.HelpFormatter
 	}
+	if f, ok := formatter.(layoutFormatter); ok {
+		return f.layout().format(p, w)
+	}
+	return formatter.FormatHelp(p)
+}
 
-	return join("", usage...)
+// GetUsage returns the usage line of the parser's help text, which summarizes
+// the parser's options and commands.
+func (p *Parser) GetUsage() string {
+	return p.usageLine(p.styleFor(p.outWriter()))
+}
+
+// usageLine returns the parser's usage line, styled using the provided theme.
+func (p *Parser) usageLine(theme *Theme) string {
+	l := helpLayout{theme: theme, catalog: p.catalog()}
+	notPositional, positional, commandStr := splitOptions(p)
+	return l.usage(p, notPositional, positional, commandStr, p.screenWidth())
 }
 
 // GetVersion will return the version text for the current parser.
@@ -258,128 +478,384 @@ func (p *Parser) GetVersion() string {
 }
 
 // Parse accepts a slice of strings as options and arguments to be parsed. The
-// parser will call each encountered option's action. Unexpected options will
-// cause an error. All errors are returned.
+// parser will call each encountered option's action, using the arguments
+// following the option, while any other arguments are given to positional
+// options. Unexpected options will cause an error. Parsing stops at the first error, unless the parser collects
+// errors, in which case all errors are returned within a MultiError.
 func (p *Parser) Parse(allArgs ...string) {
+	parser, args, err := p.parse(allArgs, 0, nil, nil)
+	if err == nil {
+		err = parser.postParse(args)
+	}
+	if err != nil && parser.exitOnError() {
+		parser.exitWithError(err)
+	}
+
+	for callbackParser := parser; callbackParser != nil; callbackParser = callbackParser.parent {
+		if callbackParser.Callback != nil {
+			callbackParser.Callback(parser, parser.Namespace, args, err)
+			return
+		}
+	}
+}
+
+// parse parses the arguments from the provided offset onwards, as with Parse,
+// returning the parser of the selected command, the unused arguments, and the
+// error encountered, if any. The complete arguments are used when reporting the
+// position of errors. The pending options are required persistent options of
+// the parser's parents which were not provided before the name of the parser's
+// command. The provided options are the options provided to the parser's
+// parents, mapped to the index of the argument they were provided by.
+func (p *Parser) parse(argv []string, offset int, pending map[*Option]bool, provided map[*Option]int) (*Parser, []string, error) {
+	allArgs := argv[offset:]
+
+	var errs []error
+	collect := p.collectErrors()
+
+	// fail records the provided error, and returns true if parsing should stop.
+	fail := func(err error) bool {
+		switch err.(type) {
+		case nil:
+			return false
+		case ShowHelpErr, ShowVersionErr:
+			errs = []error{err}
+			return true
+		}
+		errs = append(errs, err)
+		return !collect
+	}
+
+	// finish returns the parser, the provided arguments, and the recorded
+	// errors, if any.
+	finish := func(args []string) (*Parser, []string, error) {
+		switch {
+		case len(errs) == 0:
+			return p, args, nil
+		case !collect || len(errs) == 1 && isShowErr(errs[0]):
+			return p, args, errs[0]
+		default:
+			return p, args, MultiError{Errors: errs}
+		}
+	}
+
 	if p.Namespace == nil {
 		p.Namespace = NewNamespace()
 	}
-	requiredOptions := make(map[string]*Option)
-	remainderOptions := make(map[string]*Option)
-	var err error
+	if err := p.preParse(allArgs); err != nil {
+		errs = append(errs, err)
+		return finish(allArgs)
+	}
 
-	var optionListing []*Option
+	requiredOptions := make(map[*Option]bool)
+	for option := range pending {
+		requiredOptions[option] = true
+	}
+	var remainderOptions []*Option
 
-	if len(p.Parsers) > 0 {
-		var usedParser bool
+	// provided maps the options which were provided to this parser, or any of
+	// its parents, to the index of their first argument, for checking
+	// constraints between options.
+	if provided == nil {
+		provided = make(map[*Option]int)
+	}
 
-		for _, subParser := range p.Parsers {
-			name := subParser.Name
-			parser := subParser.Parser
-			if len(allArgs) <= 0 {
-				p.Callback(p, p.Namespace, nil, MissingParserErr{p.Parsers})
-				return
+	// Only the options preceding the command, if any, are parsed by this
+	// parser. The remaining arguments are parsed by the command's parser, or
+	// passed to the command's plugin. Without a command, the default command
+	// is parsed using no arguments.
+	var command *SubParser
+	var commandName, plugin string
+	commandOffset := len(argv)
+	if len(p.Parsers) > 0 || p.PluginsEnabled {
+		index := p.commandIndex(allArgs)
+		if index < len(allArgs) && len(p.Parsers) == 0 {
+			// Without commands, only arguments naming a plugin are commands;
+			// any others are parsed as positional arguments.
+			if plugin = p.findPlugin(allArgs[index]); len(plugin) > 0 {
+				commandOffset = offset + index + 1
+			} else {
+				index = len(allArgs)
 			}
-			if allArgs[0] == name {
-				if len(allArgs) > 0 {
-					allArgs = allArgs[1:]
-				} else {
-					allArgs = []string{}
+		} else if index < len(allArgs) {
+			subParser, suggestions, ok := p.matchParser(allArgs[index])
+			if !ok {
+				plugin = p.findPlugin(allArgs[index])
+			}
+			if !ok && len(plugin) == 0 {
+				if len(suggestions) == 0 {
+					suggestions = p.suggestCommands(allArgs[index])
 				}
-
-				parser.Parse(allArgs...)
-				return
+				err := MissingParserErr{
+					Parsers:     p.Parsers,
+					Name:        allArgs[index],
+					Suggestions: suggestions,
+				}
+				return p, nil, positionError(err, offset+index, argv)
+			}
+			if ok {
+				command = &subParser
+				commandName = allArgs[index]
+			}
+			commandOffset = offset + index + 1
+		} else if len(p.DefaultCommandName) > 0 {
+			if subParser, _, ok := p.matchParser(p.DefaultCommandName); ok {
+				command = &subParser
 			}
 		}
-
-		if !usedParser {
-			p.Callback(p, p.Namespace, nil, MissingParserErr{p.Parsers})
-			return
-		}
+		allArgs = allArgs[:index]
 	}
 
 	for _, option := range p.Options {
 		if option.IsRequired {
-			requiredOptions[option.DestName] = option
+			requiredOptions[option] = true
 		}
 
 		if isEnvVarFormat(option.DefaultVal) {
 			defVal, err := getEnvVar(option.DefaultVal)
-			if err != nil {
-				p.Callback(p, p.Namespace, allArgs, err)
-				return
+			if fail(err) {
+				return finish(allArgs)
 			}
 			p.Namespace.Set(option.DestName, defVal)
 		} else {
 			p.Namespace.Set(option.DestName, option.DefaultVal)
 		}
 		if strings.ToLower(option.ArgNum) == "r" {
-			remainderOptions[option.DisplayName()] = option
+			remainderOptions = append(remainderOptions, option)
+		}
+	}
+
+	// Each option is given the arguments following it, up to the next option.
+	// Any arguments it does not consume, along with those preceding the first
+	// option, are parsed as positional arguments.
+	var options []token
+	var following [][]token
+	var positional []token
+	for _, t := range tokenize(allArgs...) {
+		t.Index = t.Index + offset
+		if t.IsOption {
+			options = append(options, t)
+			following = append(following, nil)
+		} else if len(options) > 0 {
+			following[len(following)-1] = append(following[len(following)-1], t)
 		} else {
-			optionListing = append(optionListing, option)
+			positional = append(positional, t)
 		}
 	}
 
-	optionNames, args := extractOptions(allArgs...)
-	for _, optionName := range optionNames {
-		var option *Option
+	var args []string
+	var argIndexes []int
+
+	// use replaces the arguments not yet consumed with the provided tokens.
+	use := func(tokens []token) {
+		args, argIndexes = nil, nil
+		for _, t := range tokens {
+			args = append(args, t.Value)
+			argIndexes = append(argIndexes, t.Index)
+		}
+	}
 
-		for _, f := range p.Options {
-			if f.IsPositional {
-				continue
+	// unused returns the arguments not yet consumed as tokens.
+	unused := func() []token {
+		var tokens []token
+		for i, arg := range args {
+			if i < len(argIndexes) {
+				tokens = append(tokens, token{Value: arg, Index: argIndexes[i]})
 			}
-			if f.IsPublicName(optionName) {
-				if _, ok := requiredOptions[f.DisplayName()]; ok {
-					delete(requiredOptions, f.DisplayName())
-				} else if _, ok := remainderOptions[f.DisplayName()]; ok {
-					continue
-				}
-				option = f
+		}
+		return tokens
+	}
+
+	// advance replaces the arguments not yet consumed with those remaining
+	// after an action, keeping the index of each argument.
+	advance := func(remaining []string) {
+		consumed := len(args) - len(remaining)
+		if consumed < 0 || consumed > len(argIndexes) {
+			consumed = 0
+		}
+		args, argIndexes = remaining, argIndexes[consumed:]
+	}
+
+	// locate populates the position of the provided error, using the index of
+	// the option being parsed, or of the offending argument if known.
+	locate := func(err error, optionIndex int) error {
+		index := optionIndex
+		var value string
+
+		switch e := err.(type) {
+		case InvalidChoiceErr:
+			value, index = e.Arg, -1
+		case InvalidTypeErr:
+			value, index = e.Arg, -1
+		case ValidationErr:
+			value, index = e.Arg, -1
+		case MissingParserErr:
+			value, index = e.Name, -1
+		}
+		for i, arg := range args {
+			if len(value) > 0 && arg == value && i < len(argIndexes) {
+				index = argIndexes[i]
 				break
 			}
 		}
 
+		return positionError(err, index, argv)
+	}
+
+	for i, t := range options {
+		use(following[i])
+
+		option := p.findOption(t.Value)
+		if option != nil && option.IsDeprecated {
+			p.warnDeprecated(MsgDeprecatedOption, optionName(t.Value), option.DeprecationText)
+		}
 		if option == nil {
-			p.Callback(p, p.Namespace, args, InvalidOptionErr{optionName})
-			return
+			err := InvalidOptionErr{Name: t.Value, Suggestions: p.suggestOptions(t.Value)}
+			if fail(locate(err, t.Index)) {
+				return finish(args)
+			}
+			positional = append(positional, unused()...)
+			continue
+		}
+
+		delete(requiredOptions, option)
+		provided[option] = t.Index
+		if strings.ToLower(option.ArgNum) == "r" {
+			// Remainder options are given the remaining arguments later on.
+			positional = append(positional, unused()...)
+			continue
 		}
 
-		args, err = option.DesiredAction(p, option, args...)
-		p.Callback(p, p.Namespace, args, err)
-		return
+		remaining, err := option.DesiredAction(p, option, args...)
+		if fail(locate(err, t.Index)) {
+			return finish(args)
+		}
+		if err != nil {
+			remaining = skipArgs(option, args)
+		}
+		advance(remaining)
+		positional = append(positional, unused()...)
 	}
+	use(positional)
 
 	if len(args) > 0 {
 		for _, opt := range remainderOptions {
-			if _, ok := requiredOptions[opt.DisplayName()]; ok {
-				delete(requiredOptions, opt.DisplayName())
+			delete(requiredOptions, opt)
+			provided[opt] = argIndexes[0]
+			if _, err := opt.DesiredAction(p, opt, args...); fail(locate(err, -1)) {
+				return finish(args)
 			}
-			_, err := opt.DesiredAction(p, opt, args...)
-			p.Callback(p, p.Namespace, args, err)
-			return
 		}
 	}
 
 	for _, f := range p.Options {
-		if !f.IsPositional {
+		if !f.IsPositional || strings.ToLower(f.ArgNum) == "r" {
 			continue
 		}
-		if _, ok := requiredOptions[f.DestName]; ok {
-			delete(requiredOptions, f.DestName)
+		delete(requiredOptions, f)
+
+		remaining, err := f.DesiredAction(p, f, args...)
+		if fail(locate(err, -1)) {
+			return finish(args)
+		}
+		if err != nil {
+			remaining = skipArgs(f, args)
+		} else if len(remaining) < len(args) {
+			provided[f] = argIndexes[0]
 		}
-		args, err = f.DesiredAction(p, f, args...)
-		p.Callback(p, p.Namespace, args, err)
-		return
+		advance(remaining)
 	}
 
-	if len(requiredOptions) != 0 {
-		for _, option := range requiredOptions {
-			p.Callback(p, p.Namespace, args, MissingOptionErr{option.DisplayName()})
-			return
+	// Required persistent options can still be provided after the command's
+	// name, and so are left for the command's parser to check.
+	pending = make(map[*Option]bool)
+	for _, option := range append(p.Options[:len(p.Options):len(p.Options)], p.persistentOptions()...) {
+		if !requiredOptions[option] {
+			continue
+		}
+		if command != nil && option.IsPersistent {
+			pending[option] = true
+			continue
+		}
+		if fail(locate(MissingOptionErr{Name: option.DisplayName(), Opt: *option}, -1)) {
+			return finish(args)
+		}
+	}
+
+	// Options can be provided to any parser within the chain of commands, and
+	// so constraints are checked once, by the parser of the deepest command.
+	if command == nil || len(errs) > 0 {
+		for _, err := range p.checkConstraints(provided, argv) {
+			if fail(err) {
+				return finish(args)
+			}
+		}
+	}
+
+	if len(errs) == 0 {
+		for _, err := range p.openFiles(provided, argv) {
+			if fail(err) {
+				return finish(args)
+			}
+		}
+	}
+
+	if len(p.Parsers) > 0 && !p.OptionalCommands && command == nil && len(plugin) == 0 {
+		if fail(positionError(MissingParserErr{Parsers: p.Parsers}, -1, argv)) {
+			return finish(args)
+		}
+	}
+
+	if len(plugin) > 0 && len(errs) == 0 {
+		fail(p.runPlugin(plugin, argv[commandOffset:]))
+		return finish(args)
+	}
+
+	if command != nil && len(errs) == 0 {
+		if command.Parser.IsDeprecated && len(commandName) > 0 {
+			p.warnDeprecated(MsgDeprecatedCommand, commandName, command.Parser.DeprecationText)
+		}
+		if command.Parser.Namespace == nil {
+			command.Parser.Namespace = NewNamespace()
+		}
+		for key, value := range *p.Namespace {
+			command.Parser.Namespace.Set(key, value)
+		}
+		if dest := p.commandDest(); len(dest) > 0 {
+			// The path begins at the parser which set the destination.
+			var path []string
+			if len(p.CommandDest) == 0 {
+				path = append(path, p.Namespace.Slice(dest)...)
+			}
+			command.Parser.Namespace.Set(dest, append(path, command.Name))
+		}
+
+		parser, rest, err := command.Parser.parse(argv, commandOffset, pending, provided)
+		for key, value := range *command.Parser.Namespace {
+			p.Namespace.Set(key, value)
+		}
+		return parser, rest, err
+	}
+
+	return finish(args)
+}
+
+// CollectErrors sets whether the parser continues parsing after encountering
+// an error, so that all errors are returned at once within a MultiError.
+// Sub-parsers will collect errors if any of their parents do.
+func (p *Parser) CollectErrors(collect bool) *Parser {
+	p.CollectAllErrors = collect
+	return p
+}
+
+// collectErrors returns true if the parser, or any of its parents, collects
+// errors.
+func (p *Parser) collectErrors() bool {
+	for parser := p; parser != nil; parser = parser.parent {
+		if parser.CollectAllErrors {
+			return true
 		}
 	}
-	p.Callback(p, p.Namespace, args, nil)
-	return
+	return false
 }
 
 // Path will set the parser's program name to the program name specified by the
@@ -402,26 +878,149 @@ func (p *Parser) Epilog(text string) *Parser {
 	return p
 }
 
+// Formatter sets the HelpFormatter used to render the parser's help text.
+func (p *Parser) Formatter(formatter HelpFormatter) *Parser {
+	p.HelpFormatter = formatter
+	return p
+}
+
 // Usage sets the provide string as the usage/description text for the parser.
 func (p *Parser) Usage(usage string) *Parser {
 	p.UsageText = usage
 	return p
 }
 
-// ShowHelp outputs to stdout the parser's generated help text.
+// Output sets the writer used when displaying help and version text. Sub-parsers
+// without their own writer will use their parent's writer. By default, stdout
+// is used.
+func (p *Parser) Output(w io.Writer) *Parser {
+	p.OutWriter = w
+	return p
+}
+
+// ErrOutput sets the writer used when displaying errors. Sub-parsers without
+// their own writer will use their parent's writer. By default, stderr is used.
+func (p *Parser) ErrOutput(w io.Writer) *Parser {
+	p.ErrWriter = w
+	return p
+}
+
+// outWriter returns the writer to be used for help and version text.
+func (p *Parser) outWriter() io.Writer {
+	for parser := p; parser != nil; parser = parser.parent {
+		if parser.OutWriter != nil {
+			return parser.OutWriter
+		}
+	}
+	return os.Stdout
+}
+
+// errWriter returns the writer to be used for errors.
+func (p *Parser) errWriter() io.Writer {
+	for parser := p; parser != nil; parser = parser.parent {
+		if parser.ErrWriter != nil {
+			return parser.ErrWriter
+		}
+	}
+	return os.Stderr
+}
+
+// formatError returns the message of the provided error, or of each error within
+// a MultiError, following the provided prefix. Each message is followed by the
+// context of its offending argument, if any.
+func (p *Parser) formatError(err error, prefix string) string {
+	errs := []error{err}
+	if multiErr, ok := err.(MultiError); ok {
+		errs = multiErr.Errors
+	}
+
+	var lines []string
+	for _, e := range errs {
+		lines = append(lines, join(" ", prefix, p.localize(e)))
+		if context := ErrorContext(p.root().ProgramName, e); len(context) > 0 {
+			lines = append(lines, context)
+		}
+	}
+	return join("\n", lines...)
+}
+
+// root returns the top-most parent of the parser.
+func (p *Parser) root() *Parser {
+	parser := p
+	for parser.parent != nil {
+		parser = parser.parent
+	}
+	return parser
+}
+
+// ShowError outputs the provided error, followed by the parser's generated help
+// text, to the parser's error writer. Errors caused by a parsed argument are
+// displayed with the arguments, and a caret beneath the offending argument.
+func (p *Parser) ShowError(err error) *Parser {
+	w := p.errWriter()
+	fmt.Fprintf(w, "%s\n\n", p.formatError(err, p.styleFor(w).errorPrefix(message(p.catalog(), MsgError, 1))))
+	fmt.Fprintln(w, p.helpFor(w))
+
+	return p
+}
+
+// ShowHelp outputs the parser's generated help text to the parser's output
+// writer.
 func (p *Parser) ShowHelp() *Parser {
-	fmt.Println(p.GetHelp())
+	fmt.Fprintln(p.outWriter(), p.GetHelp())
 
 	return p
 }
 
-// ShowVersion outputs to stdout the parser's generated versioning text.
+// ShowVersion outputs the parser's generated versioning text to the parser's
+// output writer.
 func (p *Parser) ShowVersion() *Parser {
-	fmt.Println(p.GetVersion())
+	fmt.Fprintln(p.outWriter(), p.GetVersion())
 
 	return p
 }
 
+// Theme enables styling of help text and error messages using the provided
+// theme. Styling is only applied when writing to a terminal, unless overridden
+// by the `FORCE_COLOR` or `NO_COLOR` environmental variables. Sub-parsers
+// without their own theme will use their parent's theme.
+func (p *Parser) Theme(theme Theme) *Parser {
+	p.ColorTheme = &theme
+	return p
+}
+
+// styleFor returns the theme to use when writing to the provided writer, or nil
+// if output should not be styled.
+func (p *Parser) styleFor(w io.Writer) *Theme {
+	for parser := p; parser != nil; parser = parser.parent {
+		if parser.ColorTheme != nil {
+			if !colorEnabled(w) {
+				return nil
+			}
+			return parser.ColorTheme
+		}
+	}
+	return nil
+}
+
+// Width sets the screen width used when rendering help text, overriding the
+// detected width of the screen. Sub-parsers without their own width will use
+// their parent's width. A width of zero restores detection.
+func (p *Parser) Width(width int) *Parser {
+	p.ScreenWidth = width
+	return p
+}
+
+// screenWidth returns the width to be used when rendering help text.
+func (p *Parser) screenWidth() int {
+	for parser := p; parser != nil; parser = parser.parent {
+		if parser.ScreenWidth > 0 {
+			return parser.ScreenWidth
+		}
+	}
+	return getScreenWidth()
+}
+
 // Version sets the provide string as the version text for the parser.
 func (p *Parser) Version(version string) *Parser {
 	p.VersionDesc = version
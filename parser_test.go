@@ -2,7 +2,11 @@ package argparse
 
 import (
 	"bufio"
+	"bytes"
+	"errors"
 	"os"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -125,7 +129,346 @@ func TestParserGetVersion(t *testing.T) {
 // the parser are properly parsed and the necessary actions for all options are
 // executed.
 func TestParserParse(t *testing.T) {
-	// TODO: create an actual test.
+	var ns *Namespace
+	var leftovers []string
+	var err error
+	callback := func(p *Parser, n *Namespace, args []string, e error) {
+		ns, leftovers, err = n, args, e
+	}
+
+	p := NewParser("program", callback)
+	p.AddOptions(
+		NewFlag("u upper", "upper", "Use uppercase text"),
+		NewOption("t times", "times", "Number of greetings").Nargs("1").Action(Store).Required(),
+		NewArg("name", "name", "Name of person to greet").Required(),
+	)
+	p.Parse("--times", "3", "-u", "Vader", "extra")
+
+	if err != nil {
+		t.Fatalf("An unexpected error occurred: %s", err.Error())
+	}
+	if ns.String("upper") != "true" {
+		t.Errorf("Expected upper to be \"true\", but received: %s", ns.String("upper"))
+	}
+	if ns.String("times") != "3" {
+		t.Errorf("Expected times to be \"3\", but received: %s", ns.String("times"))
+	}
+	if ns.String("name") != "Vader" {
+		t.Errorf("Expected name to be \"Vader\", but received: %s", ns.String("name"))
+	}
+	if len(leftovers) != 1 || leftovers[0] != "extra" {
+		t.Errorf("Expected the leftover argument \"extra\", but received: %v", leftovers)
+	}
+
+	p.Parse("Vader")
+	if _, ok := err.(MissingOptionErr); !ok {
+		t.Errorf("Expected a MissingOptionErr, but received: %v", err)
+	}
+}
+
+// TestParserParse_OptionArguments tests to ensure options are given the
+// arguments following them, regardless of where positional arguments are
+// provided.
+func TestParserParse_OptionArguments(t *testing.T) {
+	var ns *Namespace
+	var err error
+	callback := func(p *Parser, n *Namespace, args []string, e error) {
+		ns, err = n, e
+	}
+
+	p := NewParser("program", callback)
+	p.AddOptions(
+		NewFlag("u upper", "upper", "Use uppercase text"),
+		NewOption("t times", "times", "Number of greetings").Nargs("1").Action(Store).Required(),
+		NewArg("name", "name", "Name of person to greet"),
+	)
+
+	for _, args := range [][]string{
+		{"Vader", "--times", "3"},
+		{"Vader", "-ut", "3"},
+		{"-u", "Vader", "-t", "3"},
+	} {
+		p.Parse(args...)
+		if err != nil {
+			t.Fatalf("%v: An unexpected error occurred: %s", args, err.Error())
+		}
+		if ns.String("times") != "3" || ns.String("name") != "Vader" {
+			t.Errorf("%v: Expected times \"3\" and name \"Vader\", but received: %s, %s", args, ns.String("times"), ns.String("name"))
+		}
+	}
+
+	p.Parse("Vader", "--times")
+	if tooFew, ok := err.(TooFewArgsErr); !ok || tooFew.Index() != 1 {
+		t.Errorf("Expected a TooFewArgsErr at index 1, but received: %v", err)
+	}
+}
+
+// TestParserParse_CollectErrors tests to ensure that parsing stops at the first
+// error by default, and that all errors are returned when collecting errors.
+func TestParserParse_CollectErrors(t *testing.T) {
+	var err error
+	callback := func(p *Parser, n *Namespace, args []string, e error) {
+		err = e
+	}
+
+	p := NewParser("program", callback)
+	p.AddOptions(
+		NewOption("size", "size", "Size").Nargs("1").Action(Store).Choices("s", "m", "l"),
+		NewOption("count", "count", "Count").Nargs("1").Action(Store).Type(reflect.Int),
+		NewOption("name", "name", "Name").Nargs("1").Action(Store).Required(),
+	)
+
+	p.Parse("--size", "xl", "--count", "abc", "--bogus")
+	if _, ok := err.(InvalidChoiceErr); !ok {
+		t.Fatalf("Expected an InvalidChoiceErr, but received: %v", err)
+	}
+
+	p.CollectErrors(true).Parse("--size", "xl", "--count", "abc", "--bogus")
+	multiErr, ok := err.(MultiError)
+	if !ok {
+		t.Fatalf("Expected a MultiError, but received: %v", err)
+	}
+
+	expected := []error{ErrInvalidChoice, ErrInvalidType, ErrInvalidOption, ErrMissingOption}
+	if len(multiErr.Errors) != len(expected) {
+		t.Fatalf("Expected %d errors, but received: %v", len(expected), multiErr.Errors)
+	}
+	for i, sentinel := range expected {
+		if !errors.Is(multiErr.Errors[i], sentinel) {
+			t.Errorf("Expected error %d to be %s, but received: %v", i, sentinel, multiErr.Errors[i])
+		}
+	}
+	if index := multiErr.Errors[1].(ParseError).Index(); index != 3 {
+		t.Errorf("Expected the invalid type at index 3, but received: %d", index)
+	}
+	if !errors.Is(multiErr, ErrMissingOption) {
+		t.Error("Expected the MultiError to match its contained errors")
+	}
+	var typeErr InvalidTypeErr
+	if !errors.As(multiErr, &typeErr) || typeErr.Arg != "abc" {
+		t.Errorf("Expected the MultiError to contain an InvalidTypeErr, but received: %v", typeErr)
+	}
+}
+
+// TestParserParse_Suggestions tests to ensure that unknown options and commands
+// result in errors suggesting similarly named options and commands.
+func TestParserParse_Suggestions(t *testing.T) {
+	var err error
+	callback := func(p *Parser, ns *Namespace, args []string, e error) {
+		err = e
+	}
+
+	p := NewParser("program", callback)
+	p.AddOptions(NewFlag("v verbose", "verbose", "Verbose output"))
+	p.Parse("--verbos")
+
+	optErr, ok := err.(InvalidOptionErr)
+	if !ok {
+		t.Fatalf("Expected an InvalidOptionErr, but received: %v", err)
+	}
+	if len(optErr.Suggestions) != 1 || optErr.Suggestions[0] != "--verbose" {
+		t.Errorf("Expected the suggestion \"--verbose\", but received: %v", optErr.Suggestions)
+	}
+	if optErr.Error() != "invalid option \"verbos\" (did you mean \"--verbose\"?)" {
+		t.Errorf("Unexpected error message: %s", optErr.Error())
+	}
+
+	p = NewParser("program", callback)
+	p.AddParser("deploy", NewParser("deploy", callback))
+	p.AddParser("destroy", NewParser("destroy", callback))
+	p.Parse("deplyo")
+
+	parserErr, ok := err.(MissingParserErr)
+	if !ok {
+		t.Fatalf("Expected a MissingParserErr, but received: %v", err)
+	}
+	if len(parserErr.Suggestions) != 1 || parserErr.Suggestions[0] != "deploy" {
+		t.Errorf("Expected the suggestion \"deploy\", but received: %v", parserErr.Suggestions)
+	}
+	if parserErr.Error() != "must use an available command: {deploy,destroy} (did you mean \"deploy\"?)" {
+		t.Errorf("Unexpected error message: %s", parserErr.Error())
+	}
+}
+
+// TestParserParse_Aliases tests to ensure commands can be used by their aliases,
+// and by unique prefixes when allowed.
+func TestParserParse_Aliases(t *testing.T) {
+	var used string
+	var err error
+	command := func(name string) *Parser {
+		return NewParser(name, func(p *Parser, ns *Namespace, args []string, e error) {
+			used, err = name, e
+		})
+	}
+
+	p := NewParser("program", func(p *Parser, ns *Namespace, args []string, e error) {
+		used, err = "", e
+	})
+	p.AddParserWithAliases("remove", []string{"rm"}, command("remove"))
+	p.AddParserWithAliases("list", []string{"ls"}, command("list"))
+	p.AddParser("deploy", command("deploy"))
+	p.AddParser("destroy", command("destroy"))
+
+	if help := p.GetHelp(); !strings.Contains(help, "{remove,rm,list,ls,deploy,destroy}") {
+		t.Errorf("Expected the aliases within the command list:\n%s", help)
+	}
+
+	p.Parse("rm")
+	if used != "remove" || err != nil {
+		t.Errorf("Expected the \"remove\" command, but received \"%s\": %v", used, err)
+	}
+
+	p.Parse("dep")
+	if _, ok := err.(MissingParserErr); !ok {
+		t.Errorf("Expected a MissingParserErr without prefixes, but received: %v", err)
+	}
+
+	p.AllowCommandPrefixes(true)
+	p.Parse("dep")
+	if used != "deploy" || err != nil {
+		t.Errorf("Expected the \"deploy\" command, but received \"%s\": %v", used, err)
+	}
+
+	p.Parse("de")
+	parserErr, ok := err.(MissingParserErr)
+	if !ok {
+		t.Fatalf("Expected a MissingParserErr for an ambiguous prefix, but received: %v", err)
+	}
+	if strings.Join(parserErr.Suggestions, ",") != "deploy,destroy" {
+		t.Errorf("Expected the ambiguous commands as suggestions, but received: %v", parserErr.Suggestions)
+	}
+
+	if subP, err := p.GetParser("ls"); err != nil || subP.UsageText != "list" {
+		t.Errorf("Expected the \"list\" command by its alias, but received: %v", err)
+	}
+}
+
+// TestParserParse_GlobalOptions tests to ensure the parent's options can precede
+// the command's name, and persistent options can follow it, with their values
+// visible within the command's namespace.
+func TestParserParse_GlobalOptions(t *testing.T) {
+	var ns *Namespace
+	var err error
+	callback := func(p *Parser, n *Namespace, args []string, e error) {
+		ns, err = n, e
+	}
+
+	deploy := NewParser("deploy", callback)
+	deploy.AddOption(NewOption("replicas", "replicas", "Replicas").Nargs("1").Action(Store))
+
+	p := NewParser("program", callback)
+	p.AddOption(NewFlag("v verbose", "verbose", "Verbose output"))
+	p.AddOption(NewOption("c context", "context", "Context").Nargs("1").Action(Store).Persistent().Required())
+	p.AddParser("deploy", deploy)
+
+	p.Parse("--verbose", "-c", "prod", "deploy", "--replicas", "3")
+	if err != nil {
+		t.Fatalf("An unexpected error occurred: %v", err)
+	}
+	for key, expected := range map[string]string{"verbose": "true", "context": "prod", "replicas": "3"} {
+		if value := ns.String(key); value != expected {
+			t.Errorf("Expected \"%s\" to be \"%s\", but received \"%s\"", key, expected, value)
+		}
+	}
+
+	p.Parse("deploy", "--context", "dev")
+	if err != nil {
+		t.Fatalf("An unexpected error occurred: %v", err)
+	}
+	if value := ns.String("context"); value != "dev" {
+		t.Errorf("Expected the persistent option after the command, but received \"%s\"", value)
+	}
+
+	p.Parse("deploy", "--verbose", "-c", "dev")
+	if optErr, ok := err.(InvalidOptionErr); !ok || optErr.Index() != 1 {
+		t.Errorf("Expected an InvalidOptionErr at index 1 for a non-persistent option, but received: %v", err)
+	}
+
+	p.Parse("--verbose", "deploy")
+	if !errors.Is(err, ErrMissingOption) {
+		t.Errorf("Expected the required persistent option to be missing, but received: %v", err)
+	}
+
+	p.Parse("-c", "dev")
+	if !errors.Is(err, ErrMissingParser) {
+		t.Errorf("Expected a missing command, but received: %v", err)
+	}
+}
+
+// TestParserAddSubparsers tests to ensure the path of selected commands is
+// recorded within the namespace, and that a single callback can handle every
+// command using the merged namespace.
+func TestParserAddSubparsers(t *testing.T) {
+	var parser *Parser
+	var ns *Namespace
+	var err error
+	callback := func(p *Parser, n *Namespace, args []string, e error) {
+		parser, ns, err = p, n, e
+	}
+
+	scale := NewParser("scale", nil)
+	scale.AddOption(NewOption("replicas", "replicas", "Replicas").Nargs("1").Action(Store))
+	cluster := NewParser("cluster", nil)
+	cluster.AddParserWithAliases("scale", []string{"sc"}, scale)
+
+	p := NewParser("program", callback).AddSubparsers("command")
+	p.AddOption(NewFlag("v verbose", "verbose", "Verbose output"))
+	p.AddParser("cluster", cluster)
+
+	for i := 0; i < 2; i++ {
+		p.Parse("-v", "cluster", "sc", "--replicas", "3")
+		if err != nil {
+			t.Fatalf("An unexpected error occurred: %v", err)
+		}
+		if parser != scale {
+			t.Errorf("Expected the callback to receive the \"scale\" parser")
+		}
+		if path := ns.Slice("command"); strings.Join(path, " ") != "cluster scale" {
+			t.Errorf("Expected the command path \"cluster scale\", but received: %v", path)
+		}
+		if ns.String("verbose") != "true" || ns.String("replicas") != "3" {
+			t.Errorf("Expected the merged namespace, but received: %v", *ns)
+		}
+	}
+
+	if path := p.Namespace.Slice("command"); strings.Join(path, " ") != "cluster scale" {
+		t.Errorf("Expected the command path within the parent's namespace, but received: %v", path)
+	}
+}
+
+// TestParserDefaultCommand tests to ensure the default command is used when no
+// command is provided, and that commands can be made optional.
+func TestParserDefaultCommand(t *testing.T) {
+	var parser *Parser
+	var ns *Namespace
+	var err error
+	callback := func(p *Parser, n *Namespace, args []string, e error) {
+		parser, ns, err = p, n, e
+	}
+
+	status := NewParser("status", callback)
+	p := NewParser("program", callback).Prog("prog").DefaultCommand("status")
+	p.AddOption(NewFlag("v verbose", "verbose", "Verbose output"))
+	p.AddParser("status", status)
+	p.AddParser("deploy", NewParser("deploy", callback))
+
+	p.Parse("--verbose")
+	if err != nil || parser != status {
+		t.Fatalf("Expected the default command to be used, but received: %v", err)
+	}
+	if ns.String("verbose") != "true" {
+		t.Errorf("Expected the parent's options to be parsed, but received: %v", *ns)
+	}
+	if usage := p.GetUsage(); usage != "usage: prog [-v] [{status,deploy}]" {
+		t.Errorf("Expected the commands to be optional within the usage, but received: %s", usage)
+	}
+
+	p = NewParser("program", callback).CommandsOptional()
+	p.AddParser("status", status)
+	p.Parse()
+	if err != nil || parser != p {
+		t.Errorf("Expected the parent to be used without a command, but received: %v", err)
+	}
 }
 
 // TestParserPath tests the Path method to ensure that providing a filepath will
@@ -264,6 +607,62 @@ func TestParserShowVersion(t *testing.T) {
 	}
 }
 
+// TestParserOutput tests to ensure help and version text are written to the
+// parser's output writer, and that sub-parsers inherit the writer.
+func TestParserOutput(t *testing.T) {
+	var buff bytes.Buffer
+	child := NewParser("child program", emptyNamespace()).Version("1.0")
+	p := NewParser("this is a program", emptyNamespace()).Prog("prog").Output(&buff)
+	p.AddParser("child", child)
+
+	p.ShowHelp()
+	if !strings.HasPrefix(buff.String(), "usage: prog") {
+		t.Errorf("Expected help text to be written to the output writer, but received: %s", buff.String())
+	}
+
+	buff.Reset()
+	child.Prog("child").ShowVersion()
+	if buff.String() != "child version 1.0\n" {
+		t.Errorf("Expected version text to be written to the parent's writer, but received: %s", buff.String())
+	}
+}
+
+// TestParserErrOutput tests to ensure errors are written to the parser's
+// error writer, and that sub-parsers inherit the writer.
+func TestParserErrOutput(t *testing.T) {
+	var out, errOut bytes.Buffer
+	child := NewParser("child program", emptyNamespace())
+	p := NewParser("this is a program", emptyNamespace()).Output(&out).ErrOutput(&errOut)
+	p.AddParser("child", child)
+
+	child.ShowError(errors.New("something went wrong"))
+	if !strings.HasPrefix(errOut.String(), "error: something went wrong\n\nusage:") {
+		t.Errorf("Expected the error and help text to be written to the error writer, but received: %s", errOut.String())
+	}
+	if out.Len() != 0 {
+		t.Errorf("Expected nothing to be written to the output writer, but received: %s", out.String())
+	}
+}
+
+// TestParserWidth tests to ensure the parser's width overrides the detected
+// screen width, and is inherited by sub-parsers.
+func TestParserWidth(t *testing.T) {
+	t.Setenv("COLUMNS", "132")
+
+	child := NewParser("child program", emptyNamespace())
+	p := NewParser("this is a program", emptyNamespace())
+	p.AddParser("child", child)
+
+	if width := child.screenWidth(); width != 132 {
+		t.Errorf("Expected the detected screen width of 132, but received: %d", width)
+	}
+
+	p.Width(40)
+	if width := child.screenWidth(); width != 40 {
+		t.Errorf("Expected the parent's screen width of 40, but received: %d", width)
+	}
+}
+
 // TestNewParser tests to ensure a new parser with a populated description
 // is returned using the NewParser function.
 func TestNewParser(t *testing.T) {
@@ -278,3 +677,127 @@ func TestNewParser(t *testing.T) {
 		t.Errorf("The parser's usage text: '%s' does not match the expected description: '%s'", p.UsageText, desc)
 	}
 }
+
+// TestParserAddHelpCommand tests to ensure the help command outputs the help
+// text of nested commands, and suggests similar names for unknown commands.
+func TestParserAddHelpCommand(t *testing.T) {
+	var ns *Namespace
+	var err error
+	callback := func(p *Parser, n *Namespace, args []string, e error) {
+		ns, err = n, e
+	}
+
+	var out bytes.Buffer
+	scale := NewParser("Scale a cluster", callback)
+	cluster := NewParser("Manage clusters", callback)
+	cluster.AddParser("scale", scale)
+	p := NewParser("program", callback).Prog("prog").Output(&out).AddSubparsers("command").AddHelpCommand()
+	p.AddParser("cluster", cluster)
+
+	p.Parse("help", "cluster", "scale")
+	if _, ok := err.(ShowHelpErr); !ok {
+		t.Errorf("Expected a ShowHelpErr, but received: %v", err)
+	}
+	if !strings.HasPrefix(out.String(), "usage: prog cluster scale") || !strings.Contains(out.String(), "Scale a cluster") {
+		t.Errorf("Expected the help text of the nested command, but received:\n%s", out.String())
+	}
+
+	out.Reset()
+	p.Parse("help")
+	if !strings.HasPrefix(out.String(), "usage: prog") || !strings.Contains(out.String(), "Show help for a command") {
+		t.Errorf("Expected the parser's help text, but received:\n%s", out.String())
+	}
+	if path := ns.Slice("command"); strings.Join(path, " ") != "help" {
+		t.Errorf("Expected the command path \"help\", but received: %v", path)
+	}
+
+	p.Parse("help", "cluster", "scael")
+	parserErr, ok := err.(MissingParserErr)
+	if !ok || len(parserErr.Suggestions) != 1 || parserErr.Suggestions[0] != "scale" {
+		t.Errorf("Expected a MissingParserErr suggesting \"scale\", but received: %v", err)
+	} else if parserErr.Index() != 2 {
+		t.Errorf("Expected the error at index 2, but received %d", parserErr.Index())
+	}
+}
+
+// TestParserHidden tests to ensure hidden options and commands are excluded
+// from help text and suggestions, while still being parsed.
+func TestParserHidden(t *testing.T) {
+	var ns *Namespace
+	var err error
+	callback := func(p *Parser, n *Namespace, args []string, e error) {
+		ns, err = n, e
+	}
+
+	p := NewParser("program", callback).Prog("prog")
+	p.AddOption(NewFlag("debug", "debug", "Debug output").Hidden())
+	p.AddOption(NewFlag("v verbose", "verbose", "Verbose output"))
+	p.AddParser("deploy", NewParser("deploy", callback))
+	p.AddParser("internal", NewParser("internal", callback).Hidden())
+
+	help := p.GetHelp()
+	if strings.Contains(help, "debug") || strings.Contains(help, "internal") {
+		t.Errorf("Expected the hidden option and command to be excluded:\n%s", help)
+	}
+	if !strings.HasPrefix(help, "usage: prog [-v] {deploy}") {
+		t.Errorf("Expected the hidden option and command to be excluded from the usage:\n%s", help)
+	}
+
+	p.Parse("--debug", "internal")
+	if err != nil || ns.String("debug") != "true" {
+		t.Errorf("Expected the hidden option and command to be parsed, but received: %v", err)
+	}
+
+	p.Parse("--debu", "deploy")
+	if optErr, ok := err.(InvalidOptionErr); !ok || len(optErr.Suggestions) > 0 {
+		t.Errorf("Expected no suggestions of hidden options, but received: %v", err)
+	}
+
+	var parser *Parser
+	p = NewParser("program", func(p *Parser, n *Namespace, args []string, e error) {
+		parser, err = p, e
+	}).AllowCommandPrefixes(true)
+	deploy := NewParser("deploy", nil)
+	debug := NewParser("debug", nil).Hidden()
+	p.AddParser("deploy", deploy)
+	p.AddParserWithAliases("debug-internal", []string{"dbg"}, debug)
+
+	p.Parse("de")
+	if err != nil || parser != deploy {
+		t.Errorf("Expected the prefix to match the visible command only, but received: %v", err)
+	}
+	p.Parse("deb")
+	if parserErr, ok := err.(MissingParserErr); !ok || len(parserErr.Suggestions) > 0 {
+		t.Errorf("Expected a prefix not to match or suggest the hidden command, but received: %v", err)
+	}
+	p.Parse("dbg")
+	if err != nil || parser != debug {
+		t.Errorf("Expected the hidden command to match its exact alias, but received: %v", err)
+	}
+}
+
+// TestParserDeprecated tests to ensure deprecated options and commands can be
+// used, and that a warning is written to the error writer.
+func TestParserDeprecated(t *testing.T) {
+	var ns *Namespace
+	var err error
+	callback := func(p *Parser, n *Namespace, args []string, e error) {
+		ns, err = n, e
+	}
+
+	var errOut bytes.Buffer
+	p := NewParser("program", callback).Prog("prog").ErrOutput(&errOut)
+	p.AddOption(NewOption("o old", "name", "Name").Nargs("1").Action(Store).Deprecated("use --name instead"))
+	p.AddParser("rollout", NewParser("rollout", callback).Deprecated(""))
+
+	p.Parse("--old", "x", "rollout")
+	if err != nil || ns.String("name") != "x" {
+		t.Errorf("Expected the deprecated option to be parsed, but received: %v", err)
+	}
+
+	expected := "prog: warning: option \"--old\" is deprecated: use --name instead\n" +
+		"prog: warning: command \"rollout\" is deprecated\n"
+	if errOut.String() != expected {
+		t.Errorf("Expected the warnings:\n%s\nbut received:\n%s", expected, errOut.String())
+	}
+}
@@ -0,0 +1,141 @@
+package argparse
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+)
+
+// AllowPlugins sets whether unknown commands are run as external plugins. When
+// allowed, an unknown command such as "deploy" is looked up as an executable
+// named `<ProgramName>-deploy`, which is run with the arguments following the
+// command. The program then exits with the plugin's exit status. For commands
+// beneath sub-parsers, the executable's name includes the path of commands,
+// such as `<ProgramName>-cluster-deploy`. Available plugins are listed within
+// the parser's help text.
+func (p *Parser) AllowPlugins(allow bool) *Parser {
+	p.PluginsEnabled = allow
+	return p
+}
+
+// PluginDirs sets the directories searched for plugins, instead of the
+// directories within the `PATH` environmental variable.
+func (p *Parser) PluginDirs(dirs ...string) *Parser {
+	p.PluginPaths = dirs
+	return p
+}
+
+// pluginDirs returns the directories to be searched for plugins.
+func (p *Parser) pluginDirs() []string {
+	if len(p.PluginPaths) > 0 {
+		return p.PluginPaths
+	}
+	return filepath.SplitList(os.Getenv("PATH"))
+}
+
+// pluginPrefix returns the prefix of the names of the parser's plugins, made
+// up of the top-most parser's program name and the path of commands leading to
+// the parser.
+func (p *Parser) pluginPrefix() string {
+	var names []string
+	for parser := p; parser.parent != nil; parser = parser.parent {
+		for _, subP := range parser.parent.Parsers {
+			if subP.Parser == parser {
+				names = append([]string{subP.Name}, names...)
+				break
+			}
+		}
+	}
+	return join("-", append([]string{p.root().ProgramName}, names...)...) + "-"
+}
+
+// findPlugin returns the path of the executable for the plugin of the provided
+// command, or an empty string if plugins are not allowed or none is found.
+func (p *Parser) findPlugin(name string) string {
+	if !p.PluginsEnabled || len(name) == 0 || strings.ContainsAny(name, `/\`) {
+		return ""
+	}
+
+	for _, dir := range p.pluginDirs() {
+		if len(dir) == 0 {
+			continue
+		}
+		if path, err := exec.LookPath(filepath.Join(dir, p.pluginPrefix()+name)); err == nil {
+			return path
+		}
+	}
+	return ""
+}
+
+// plugins returns the sorted names of the plugin commands available to the
+// parser, excluding those which share a name with one of its commands, or which
+// are plugins of one of its commands.
+func (p *Parser) plugins() []string {
+	if !p.PluginsEnabled {
+		return nil
+	}
+
+	prefix := p.pluginPrefix()
+	found := make(map[string]bool)
+	for _, dir := range p.pluginDirs() {
+		entries, err := os.ReadDir(dir)
+		if len(dir) == 0 || err != nil {
+			continue
+		}
+
+		for _, entry := range entries {
+			name := entry.Name()
+			if runtime.GOOS == "windows" {
+				name = strings.TrimSuffix(name, filepath.Ext(name))
+			}
+			if !strings.HasPrefix(name, prefix) || len(name) == len(prefix) {
+				continue
+			}
+			if _, err := exec.LookPath(filepath.Join(dir, entry.Name())); err != nil {
+				continue
+			}
+			command := strings.SplitN(name[len(prefix):], "-", 2)[0]
+			if _, _, ok := p.matchParser(command); !ok {
+				found[name[len(prefix):]] = true
+			}
+		}
+	}
+
+	var names []string
+	for name := range found {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// runPlugin runs the plugin executable with the provided arguments, using the
+// parser's output and error writers, and exits with the plugin's exit status.
+// An error is returned if the plugin could not be run.
+func (p *Parser) runPlugin(path string, args []string) error {
+	cmd := exec.Command(path, args...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = p.outWriter()
+	cmd.Stderr = p.errWriter()
+
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		// A negative exit code indicates the plugin was terminated by a signal.
+		code := exitErr.ExitCode()
+		if code < 0 {
+			code = 1
+		}
+		exit(code)
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	exit(ExitSuccess)
+	return nil
+}
@@ -0,0 +1,102 @@
+package argparse
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// writePlugin writes an executable shell script with the provided name and
+// body into the directory.
+func writePlugin(t *testing.T, dir, name, body string) {
+	script := join("\n", "#!/bin/sh", body, "")
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(script), 0755); err != nil {
+		t.Fatalf("An unexpected error occurred: %s", err.Error())
+	}
+}
+
+// TestParserPlugins tests to ensure unknown commands are run as plugins, with
+// their exit status forwarded, and that plugins are listed within help text.
+func TestParserPlugins(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Shell script plugins cannot be run on Windows")
+	}
+	code := captureExit(t)
+
+	dir := t.TempDir()
+	writePlugin(t, dir, "prog-hello", `echo "hello $*"; exit 3`)
+	writePlugin(t, dir, "prog-cluster-scale", `echo "scaling $*"`)
+
+	var err error
+	callback := func(p *Parser, ns *Namespace, args []string, e error) {
+		err = e
+	}
+
+	var out bytes.Buffer
+	cluster := NewParser("cluster", callback).AllowPlugins(true).PluginDirs(dir)
+	p := NewParser("program", callback).Prog("prog").Output(&out).Width(80)
+	p.AddOption(NewFlag("v verbose", "verbose", "Verbose output"))
+	p.AddParser("cluster", cluster)
+	p.AllowPlugins(true).PluginDirs(dir)
+
+	p.Parse("-v", "hello", "a", "--b")
+	if *code != 3 || out.String() != "hello a --b\n" {
+		t.Errorf("Expected the plugin to exit with 3, but exited with %d and output: %s", *code, out.String())
+	}
+
+	out.Reset()
+	p.Parse("cluster", "scale", "5")
+	if *code != 0 || out.String() != "scaling 5\n" {
+		t.Errorf("Expected the nested plugin to exit with 0, but exited with %d and output: %s", *code, out.String())
+	}
+
+	if help := p.GetHelp(); !strings.Contains(help, "plugins:\n  hello") {
+		t.Errorf("Expected the plugins within the help text:\n%s", help)
+	}
+
+	p.Parse("goodbye")
+	if !errors.Is(err, ErrMissingParser) {
+		t.Errorf("Expected a missing command for an unknown plugin, but received: %v", err)
+	}
+}
+
+// TestParserPlugins_Positional tests to ensure that, without commands, only
+// arguments naming a plugin are run as plugins, while any others are parsed as
+// positional arguments.
+func TestParserPlugins_Positional(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Shell script plugins cannot be run on Windows")
+	}
+	code := captureExit(t)
+
+	dir := t.TempDir()
+	writePlugin(t, dir, "prog-hello", `echo "hello $*"`)
+
+	var ns *Namespace
+	var err error
+	var out bytes.Buffer
+	p := NewParser("program", func(p *Parser, n *Namespace, args []string, e error) {
+		ns, err = n, e
+	}).Prog("prog").Output(&out).AllowPlugins(true).PluginDirs(dir)
+	p.AddOption(NewArg("input", "input", "Input file").NotRequired())
+
+	p.Parse("input.txt")
+	if err != nil || ns.String("input") != "input.txt" {
+		t.Errorf("Expected the argument to be parsed as a positional argument, but received: %v", err)
+	}
+
+	p.Parse()
+	if err != nil {
+		t.Errorf("Did not expect a missing command without commands, but received: %v", err)
+	}
+
+	*code = -1
+	p.Parse("hello", "world")
+	if *code != 0 || out.String() != "hello world\n" {
+		t.Errorf("Expected the plugin to be run, but exited with %d and output: %s", *code, out.String())
+	}
+}
@@ -0,0 +1,96 @@
+package argparse
+
+import (
+	"io"
+	"os"
+	"regexp"
+)
+
+// Theme contains the ANSI escape sequences used to style help text and error
+// messages. Empty sequences leave the associated text unstyled.
+type Theme struct {
+	Heading string // Section headings, such as "usage:".
+	Option  string // Option and command names.
+	Default string // Default values of options.
+	Error   string // The prefix of error messages.
+}
+
+// DefaultTheme styles headings in bold, option names in cyan, default values
+// as dimmed, and error prefixes in bold red.
+var DefaultTheme = Theme{
+	Heading: "\x1b[1m",
+	Option:  "\x1b[36m",
+	Default: "\x1b[2m",
+	Error:   "\x1b[1;31m",
+}
+
+// ansiReset is the escape sequence which clears all styling.
+const ansiReset = "\x1b[0m"
+
+// ansiRegex matches ANSI styling escape sequences.
+var ansiRegex = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+// stripANSI removes all ANSI styling escape sequences from the provided text.
+func stripANSI(text string) string {
+	return ansiRegex.ReplaceAllString(text, "")
+}
+
+// stylize wraps the text in the provided escape sequence, followed by a reset.
+func stylize(code, text string) string {
+	if len(code) == 0 || len(text) == 0 {
+		return text
+	}
+	return join("", code, text, ansiReset)
+}
+
+// heading returns the text styled as a section heading.
+func (t *Theme) heading(text string) string {
+	if t == nil {
+		return text
+	}
+	return stylize(t.Heading, text)
+}
+
+// option returns the text styled as an option name.
+func (t *Theme) option(text string) string {
+	if t == nil {
+		return text
+	}
+	return stylize(t.Option, text)
+}
+
+// defaultValue returns the text styled as a default value.
+func (t *Theme) defaultValue(text string) string {
+	if t == nil {
+		return text
+	}
+	return stylize(t.Default, text)
+}
+
+// errorPrefix returns the text styled as an error prefix.
+func (t *Theme) errorPrefix(text string) string {
+	if t == nil {
+		return text
+	}
+	return stylize(t.Error, text)
+}
+
+// colorEnabled determines if styled output should be written to the provided
+// writer. A non-empty `FORCE_COLOR` environmental variable, other than "0",
+// always enables styling, while a non-empty `NO_COLOR` disables it. Otherwise,
+// styling is only enabled when writing to a terminal.
+func colorEnabled(w io.Writer) bool {
+	if force := os.Getenv("FORCE_COLOR"); len(force) > 0 && force != "0" {
+		return true
+	}
+	if len(os.Getenv("NO_COLOR")) > 0 {
+		return false
+	}
+
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	_, isTerminal := terminalWidth(f)
+	return isTerminal
+}
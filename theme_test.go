@@ -0,0 +1,77 @@
+package argparse
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// TestColorEnabled tests to ensure styling is enabled and disabled based upon
+// the `FORCE_COLOR` and `NO_COLOR` environmental variables, and is otherwise
+// disabled for writers which are not terminals.
+func TestColorEnabled(t *testing.T) {
+	var buff bytes.Buffer
+
+	t.Setenv("FORCE_COLOR", "")
+	t.Setenv("NO_COLOR", "")
+	if colorEnabled(&buff) {
+		t.Error("Styling should not be enabled for non-terminal writers")
+	}
+
+	t.Setenv("FORCE_COLOR", "1")
+	if !colorEnabled(&buff) {
+		t.Error("Styling should be enabled when FORCE_COLOR is set")
+	}
+
+	t.Setenv("FORCE_COLOR", "0")
+	t.Setenv("NO_COLOR", "1")
+	if colorEnabled(&buff) {
+		t.Error("Styling should not be enabled when NO_COLOR is set")
+	}
+}
+
+// TestParserTheme tests to ensure help text and errors are styled using the
+// parser's theme when styling is enabled.
+func TestParserTheme(t *testing.T) {
+	t.Setenv("FORCE_COLOR", "1")
+
+	var out, errOut bytes.Buffer
+	p := NewParser("", emptyNamespace()).Prog("prog").Output(&out).ErrOutput(&errOut)
+	p.AddHelp()
+
+	if strings.Contains(p.GetHelp(), "\x1b[") {
+		t.Errorf("Help text should not be styled without a theme:\n%q", p.GetHelp())
+	}
+
+	p.Theme(DefaultTheme)
+	help := p.GetHelp()
+	expected := []string{
+		"\x1b[1musage:\x1b[0m prog",
+		"\x1b[1moptional arguments:\x1b[0m",
+		"  \x1b[36m-h, --help\x1b[0m  Show program help",
+	}
+	for _, text := range expected {
+		if !strings.Contains(help, text) {
+			t.Errorf("Expected help text to contain: %q\n%q", text, help)
+		}
+	}
+
+	p.ShowError(errors.New("bad things"))
+	if !strings.HasPrefix(errOut.String(), "\x1b[1;31merror:\x1b[0m bad things") {
+		t.Errorf("Expected a styled error prefix, but received: %q", errOut.String())
+	}
+}
+
+// TestStripANSI tests to ensure styling escape sequences are removed, and are
+// not included when measuring display width.
+func TestStripANSI(t *testing.T) {
+	styled := stylize(DefaultTheme.Error, "error:")
+
+	if stripANSI(styled) != "error:" {
+		t.Errorf("Expected escape sequences to be removed, but received: %q", stripANSI(styled))
+	}
+	if displayWidth(styled) != 6 {
+		t.Errorf("Expected a display width of 6, but received: %d", displayWidth(styled))
+	}
+}
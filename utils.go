@@ -4,15 +4,28 @@ import (
 	"bytes"
 	"os"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 
-	"github.com/nsf/termbox-go"
+	"github.com/mattn/go-runewidth"
 )
 
-// extractOptions will extract all options from the slice of arguments provided,
-// returning one slice of individual options, and a slice for all other arguments
-// present.
-func extractOptions(allArgs ...string) (options, args []string) {
+// token is a single option name or argument extracted from the arguments being
+// parsed, along with the index of the argument it was extracted from.
+type token struct {
+	Value    string
+	Index    int
+	IsOption bool
+}
+
+// optionRegex matches arguments which specify one or more options.
+var optionRegex = regexp.MustCompile(`^-{1,2}[a-zA-Z]+$`)
+
+// tokenize breaks the slice of arguments provided down into individual options
+// and other arguments, recording the index of the argument each came from.
+func tokenize(allArgs ...string) []token {
+	var tokens []token
 	count := 0
 	max := len(allArgs)
 
@@ -22,15 +35,14 @@ func extractOptions(allArgs ...string) (options, args []string) {
 		// If we have option-escape string, assume the next arg is supposed
 		// to be normal text instead of potentially being a option.
 		if a == "--" && len(allArgs) > count+1 {
-			args = append(args, allArgs[count+1])
+			tokens = append(tokens, token{Value: allArgs[count+1], Index: count + 1})
 			count = count + 2
 			continue
 		}
 
 		// Using a option regex, check if we have a normal param or a option.
-		optionRegex := regexp.MustCompile(`^-{1,2}[a-zA-Z]+$`)
 		if !optionRegex.MatchString(a) {
-			args = append(args, a)
+			tokens = append(tokens, token{Value: a, Index: count})
 			count++
 			continue
 		}
@@ -44,14 +56,29 @@ func extractOptions(allArgs ...string) (options, args []string) {
 		// If short-option, grab all letters individual options.
 		if isShort {
 			for _, c := range a[1:] {
-				options = append(options, string(c))
+				tokens = append(tokens, token{Value: string(c), Index: count, IsOption: true})
 			}
 		} else {
-			options = append(options, a[2:])
+			tokens = append(tokens, token{Value: a[2:], Index: count, IsOption: true})
 		}
 		count++
 	}
 
+	return tokens
+}
+
+// extractOptions will extract all options from the slice of arguments provided,
+// returning one slice of individual options, and a slice for all other arguments
+// present.
+func extractOptions(allArgs ...string) (options, args []string) {
+	for _, t := range tokenize(allArgs...) {
+		if t.IsOption {
+			options = append(options, t.Value)
+		} else {
+			args = append(args, t.Value)
+		}
+	}
+
 	return options, args
 }
 
@@ -61,20 +88,92 @@ func extractOptions(allArgs ...string) (options, args []string) {
 func getEnvVar(name string) (string, error) {
 	val, found := os.LookupEnv(name[1:])
 	if !found {
-		return "", MissingEnvVarErr{name}
+		return "", MissingEnvVarErr{VarName: name}
 	}
 	return val, nil
 }
 
+// defaultScreenWidth is the screen width used when the width of the screen
+// cannot otherwise be determined.
+const defaultScreenWidth = 80
+
 // getScreenWidth returns the width of the screen the program is executed within.
+// The `COLUMNS` environmental variable is used if set, followed by querying the
+// terminal attached to stdout or stderr. Otherwise, a default width is returned.
 func getScreenWidth() int {
-	if err := termbox.Init(); err != nil {
-		return 80
+	if columns, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && columns > 0 {
+		return columns
+	}
+
+	for _, f := range []*os.File{os.Stdout, os.Stderr} {
+		if w, ok := terminalWidth(f); ok {
+			return w
+		}
+	}
+
+	return defaultScreenWidth
+}
+
+// editDistance returns the number of single-character insertions, deletions,
+// substitutions, or transpositions of adjacent characters required to change
+// one string into the other.
+func editDistance(a, b string) int {
+	s, t := []rune(a), []rune(b)
+	d := make([][]int, len(s)+1)
+	for i := range d {
+		d[i] = make([]int, len(t)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+
+	for i := 1; i <= len(s); i++ {
+		for j := 1; j <= len(t); j++ {
+			cost := 1
+			if s[i-1] == t[j-1] {
+				cost = 0
+			}
+
+			d[i][j] = d[i-1][j] + 1
+			if d[i][j-1]+1 < d[i][j] {
+				d[i][j] = d[i][j-1] + 1
+			}
+			if d[i-1][j-1]+cost < d[i][j] {
+				d[i][j] = d[i-1][j-1] + cost
+			}
+			if i > 1 && j > 1 && s[i-1] == t[j-2] && s[i-2] == t[j-1] && d[i-2][j-2]+1 < d[i][j] {
+				d[i][j] = d[i-2][j-2] + 1
+			}
+		}
 	}
-	w, _ := termbox.Size()
-	termbox.Close()
 
-	return w
+	return d[len(s)][len(t)]
+}
+
+// suggest returns the candidates which are similar to the provided name,
+// ordered from most to least similar. Names must be at least two characters
+// long to receive suggestions.
+func suggest(name string, candidates ...string) []string {
+	max := (len([]rune(name)) + 1) / 3
+	distances := make(map[string]int)
+
+	var suggestions []string
+	for _, candidate := range candidates {
+		if _, ok := distances[candidate]; ok {
+			continue
+		}
+		distance := editDistance(name, candidate)
+		if distance > 0 && distance <= max {
+			distances[candidate] = distance
+			suggestions = append(suggestions, candidate)
+		}
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		return distances[suggestions[i]] < distances[suggestions[j]]
+	})
+	return suggestions
 }
 
 // envVarPattern allows for env variable names that begin with a `$`, and
@@ -112,6 +211,30 @@ func join(delimiter string, args ...string) string {
 	return join.String()
 }
 
+// skipArgs returns the arguments remaining after removing those which the
+// provided option would consume. It is used to continue parsing after an
+// option fails to parse its arguments.
+func skipArgs(opt *Option, args []string) []string {
+	switch opt.ArgNum {
+	case "?":
+		if len(args) > 0 {
+			return args[1:]
+		}
+		return args
+	case "*", "+", "r", "R":
+		return []string{}
+	}
+
+	num, err := strconv.Atoi(opt.ArgNum)
+	if err != nil || num <= 0 {
+		return args
+	}
+	if num > len(args) {
+		num = len(args)
+	}
+	return args[num:]
+}
+
 // spacer provides a string containing only space-characters of the
 // exact number specified.
 func spacer(length int) string {
@@ -127,31 +250,111 @@ func spacer(length int) string {
 	return buff.String()
 }
 
+// isRegionalIndicator returns true if the provided rune is a regional indicator
+// symbol, pairs of which are displayed as a single flag.
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1F1E6 && r <= 0x1F1FF
+}
+
+// graphemes breaks the provided string down into its user-perceived characters.
+// Zero-width runes, such as combining marks and variation selectors, are kept
+// with the preceding rune, as are runes joined by a zero-width joiner and pairs
+// of regional indicators.
+func graphemes(text string) []string {
+	var clusters []string
+	var cluster []rune
+
+	for _, r := range text {
+		if len(cluster) > 0 {
+			last := cluster[len(cluster)-1]
+			joined := last == '\u200d' || runewidth.RuneWidth(r) == 0
+			flag := len(cluster) == 1 && isRegionalIndicator(last) && isRegionalIndicator(r)
+
+			if !joined && !flag {
+				clusters = append(clusters, string(cluster))
+				cluster = nil
+			}
+		}
+		cluster = append(cluster, r)
+	}
+
+	if len(cluster) > 0 {
+		clusters = append(clusters, string(cluster))
+	}
+
+	return clusters
+}
+
+// displayWidth returns the number of terminal cells required to display the
+// provided string. Each character is measured by the width of its first rune,
+// except for flags, which are always two cells wide. ANSI styling escape
+// sequences are not displayed, and are not measured.
+func displayWidth(text string) int {
+	width := 0
+	for _, cluster := range graphemes(stripANSI(text)) {
+		for _, r := range cluster {
+			if isRegionalIndicator(r) {
+				width = width + 2
+			} else {
+				width = width + runewidth.RuneWidth(r)
+			}
+			break
+		}
+	}
+	return width
+}
+
+// breakWord breaks the provided word down into pieces with display widths not
+// exceeding the specified max width, without splitting any characters.
+func breakWord(word string, max int) []string {
+	if displayWidth(word) <= max {
+		return []string{word}
+	}
+
+	var pieces []string
+	var piece bytes.Buffer
+	length := 0
+
+	for _, cluster := range graphemes(word) {
+		width := displayWidth(cluster)
+		if length+width > max && piece.Len() > 0 {
+			pieces = append(pieces, piece.String())
+			piece.Reset()
+			length = 0
+		}
+		piece.WriteString(cluster)
+		length = length + width
+	}
+	pieces = append(pieces, piece.String())
+
+	return pieces
+}
+
 // wordWrap breaks the provided string down into an array of strings with
-// character-counts not exceeding the specified max length.
+// display widths not exceeding the specified max width. Words wider than the
+// max width are broken between characters.
 func wordWrap(text string, max int) []string {
 	var lines []string
 	var line []string
 
-	if len(text) <= max {
+	if max <= 0 || displayWidth(text) <= max {
 		return []string{text}
 	}
 
 	split := strings.Split(text, " ")
 	length := 0
 
-	if len(split) <= 1 {
-		return split
-	}
-
 	for _, word := range split {
-		if len(word)+length+len(line) > max {
-			lines = append(lines, join(" ", line...))
-			line = []string{word}
-			length = len(word)
-		} else {
-			length = length + len(word)
-			line = append(line, word)
+		for _, piece := range breakWord(word, max) {
+			width := displayWidth(piece)
+			if len(line) > 0 && width+length+len(line) > max {
+				lines = append(lines, join(" ", line...))
+				line = []string{piece}
+				length = width
+			} else {
+				length = length + width
+				line = append(line, piece)
+			}
 		}
 	}
 	lines = append(lines, join(" ", line...))
@@ -151,6 +151,20 @@ func TestGetScreenWidth(t *testing.T) {
 	}
 }
 
+// TestGetScreenWidth_Columns tests to ensure that the `COLUMNS` environmental
+// variable is used as the screen width when it is a positive integer.
+func TestGetScreenWidth_Columns(t *testing.T) {
+	t.Setenv("COLUMNS", "132")
+	if width := getScreenWidth(); width != 132 {
+		t.Errorf("Expected a screen width of 132, but received: %d", width)
+	}
+
+	t.Setenv("COLUMNS", "not a number")
+	if width := getScreenWidth(); width <= 0 {
+		t.Error("Retrieved screen width should be a positive, non-zero integer")
+	}
+}
+
 // TestIsEnvVarFormat is a table-test to ensure that isEnvVarFormat will
 // return the expected result for a given slice of inputs representing
 // possible environmental names.
@@ -259,3 +273,98 @@ func TestWordWrap(t *testing.T) {
 		t.Error("wordWrap did not return a slice of length 3")
 	}
 }
+
+// TestDisplayWidth tests to ensure the display width of strings is measured in
+// terminal cells rather than bytes.
+func TestDisplayWidth(t *testing.T) {
+	table := map[string]int{
+		"":      0,
+		"abc":   3,
+		"café":  4,
+		"café": 4,
+		"日本語":   6,
+		"👍":     2,
+		"👨‍👩‍👧": 2,
+		"🇯🇵":    2,
+	}
+
+	for text, expected := range table {
+		if actual := displayWidth(text); actual != expected {
+			t.Errorf("Expected \"%s\" to have a display width of %d, but received: %d", text, expected, actual)
+		}
+	}
+}
+
+// TestGraphemes tests to ensure strings are broken down by character without
+// separating combining marks, joined emoji, or flags.
+func TestGraphemes(t *testing.T) {
+	actual := graphemes("éa👨‍👩🇯🇵")
+	expected := []string{"é", "a", "👨‍👩", "🇯🇵"}
+
+	if len(actual) != len(expected) {
+		t.Fatalf("Expected %d characters, but received: %q", len(expected), actual)
+	}
+	for i := range expected {
+		if actual[i] != expected[i] {
+			t.Errorf("Expected character %q, but received: %q", expected[i], actual[i])
+		}
+	}
+}
+
+// TestWordWrap_Wide tests to ensure wide characters are wrapped by their display
+// width, and that words wider than the limit are broken between characters.
+func TestWordWrap_Wide(t *testing.T) {
+	lines := wordWrap("日本語のテキスト", 6)
+	expected := []string{"日本語", "のテキ", "スト"}
+
+	if len(lines) != len(expected) {
+		t.Fatalf("Expected %d lines, but received: %q", len(expected), lines)
+	}
+	for i := range expected {
+		if lines[i] != expected[i] {
+			t.Errorf("Expected line %q, but received: %q", expected[i], lines[i])
+		}
+	}
+}
+
+// TestEditDistance is a table-test to ensure the expected number of edits is
+// returned between pairs of strings, with transpositions counting as one edit.
+func TestEditDistance(t *testing.T) {
+	table := []struct {
+		a, b     string
+		expected int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"verbose", "verbose", 0},
+		{"verbos", "verbose", 1},
+		{"deplyo", "deploy", 1},
+		{"kitten", "sitting", 3},
+		{"名前", "名称", 1},
+	}
+
+	for _, test := range table {
+		if actual := editDistance(test.a, test.b); actual != test.expected {
+			t.Errorf(
+				"Expected an edit distance of %d between \"%s\" and \"%s\", but received: %d",
+				test.expected,
+				test.a,
+				test.b,
+				actual,
+			)
+		}
+	}
+}
+
+// TestSuggest tests to ensure only similar candidates are suggested, ordered by
+// similarity, and that very short names receive no suggestions.
+func TestSuggest(t *testing.T) {
+	suggestions := suggest("verbse", "verb", "quiet", "verbose", "verbose")
+	if len(suggestions) != 2 || suggestions[0] != "verbose" || suggestions[1] != "verb" {
+		t.Errorf("Expected suggestions [verbose verb], but received: %v", suggestions)
+	}
+
+	if suggestions := suggest("q", "v", "quiet"); len(suggestions) != 0 {
+		t.Errorf("Expected no suggestions, but received: %v", suggestions)
+	}
+}
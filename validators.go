@@ -0,0 +1,98 @@
+package argparse
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Validator is a function which returns an error if the provided argument is
+// not a valid value for an option.
+type Validator func(arg string) error
+
+// Validate appends the provided validators to the option. Each argument of the
+// option must satisfy every validator, in order, after its choices and type
+// have been validated.
+func (f *Option) Validate(validators ...Validator) *Option {
+	f.Validators = append(f.Validators, validators...)
+	return f
+}
+
+// validatorErr is returned by the provided validators, allowing its message to
+// be rendered using a Catalog. The underlying error is kept, if any.
+type validatorErr struct {
+	key   MessageKey
+	count int
+	args  []interface{}
+	err   error
+}
+
+// Error will return a string error message for the validatorErr
+func (err validatorErr) Error() string { return err.localize(English) }
+
+// localize returns the error message for the validatorErr using the catalog.
+func (err validatorErr) localize(c Catalog) string {
+	return sprintMessage(c, err.key, err.count, err.args...)
+}
+
+// Unwrap returns the underlying error, if any.
+func (err validatorErr) Unwrap() error { return err.err }
+
+// Range returns a validator requiring arguments to be numbers between the
+// minimum and maximum, inclusive.
+func Range(min, max float64) Validator {
+	return func(arg string) error {
+		value, err := strconv.ParseFloat(arg, 64)
+		if err != nil || value < min || value > max {
+			return validatorErr{key: MsgOutOfRange, count: 1, args: []interface{}{min, max}}
+		}
+		return nil
+	}
+}
+
+// Match returns a validator requiring arguments to match the regular
+// expression.
+func Match(re *regexp.Regexp) Validator {
+	return func(arg string) error {
+		if !re.MatchString(arg) {
+			return validatorErr{key: MsgNoMatch, count: 1, args: []interface{}{re.String()}}
+		}
+		return nil
+	}
+}
+
+// MinLen returns a validator requiring arguments to contain at least the
+// provided number of characters.
+func MinLen(n int) Validator {
+	return func(arg string) error {
+		if utf8.RuneCountInString(arg) < n {
+			return validatorErr{key: MsgTooShort, count: n, args: []interface{}{n}}
+		}
+		return nil
+	}
+}
+
+// MaxLen returns a validator requiring arguments to contain at most the
+// provided number of characters.
+func MaxLen(n int) Validator {
+	return func(arg string) error {
+		if utf8.RuneCountInString(arg) > n {
+			return validatorErr{key: MsgTooLong, count: n, args: []interface{}{n}}
+		}
+		return nil
+	}
+}
+
+// OneOfFold returns a validator requiring arguments to be one of the provided
+// choices, ignoring case.
+func OneOfFold(choices ...string) Validator {
+	return func(arg string) error {
+		for _, choice := range choices {
+			if strings.EqualFold(arg, choice) {
+				return nil
+			}
+		}
+		return validatorErr{key: MsgNotOneOf, count: len(choices), args: []interface{}{strings.Join(choices, ", ")}}
+	}
+}
@@ -0,0 +1,102 @@
+package argparse
+
+import (
+	"errors"
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+// TestValidateValue tests to ensure an argument not satisfying one of the
+// option's validators results in a ValidationErr wrapping the validator's error.
+func TestValidateValue(t *testing.T) {
+	errOdd := errors.New("must be even")
+	opt := NewOption("count", "count", "Count").Nargs("1").Action(Store).Type(reflect.Int)
+	opt.Validate(func(arg string) error {
+		if len(arg) > 0 && (arg[len(arg)-1]-'0')%2 == 1 {
+			return errOdd
+		}
+		return nil
+	})
+
+	if err := ValidateValue(*opt, "4"); err != nil {
+		t.Errorf("An unexpected error occurred: %v", err)
+	}
+
+	err := ValidateValue(*opt, "3")
+	if !errors.Is(err, ErrValidation) || !errors.Is(err, errOdd) {
+		t.Errorf("Expected the validator's error to be unwrapped, but received: %v", err)
+	}
+	if expected := "--count: invalid value \"3\": must be even"; err.Error() != expected {
+		t.Errorf("Expected the message \"%s\", but received \"%s\"", expected, err.Error())
+	}
+}
+
+// TestOptionValidate tests to ensure the provided validators are run by the
+// option's action, after the option's type is validated.
+func TestOptionValidate(t *testing.T) {
+	var err error
+	p := NewParser("program", func(p *Parser, ns *Namespace, args []string, e error) {
+		err = e
+	})
+	p.AddOption(NewOption("port", "port", "Port").Nargs("1").Action(Store).Type(reflect.Int).Validate(Range(1, 65535)))
+	p.AddOption(NewOption("name", "name", "Name").Nargs("1").Action(Store).Validate(Match(regexp.MustCompile(`^[a-z][a-z0-9-]*$`)), MaxLen(8)))
+	p.AddOption(NewOption("password", "password", "Password").Nargs("1").Action(Store).Validate(MinLen(4)))
+	p.AddOption(NewOption("level", "levels", "Levels").Nargs("+").Action(Store).Validate(OneOfFold("debug", "info")))
+
+	tests := []struct {
+		args    []string
+		message string
+	}{
+		{[]string{"--port", "8080", "--name", "web-1", "--password", "hunter2"}, ""},
+		{[]string{"--level", "DEBUG", "Info"}, ""},
+		{[]string{"--port", "0"}, "--port: invalid value \"0\": must be between 1 and 65535"},
+		{[]string{"--name", "Web"}, "--name: invalid value \"Web\": must match ^[a-z][a-z0-9-]*$"},
+		{[]string{"--name", "webserver"}, "--name: invalid value \"webserver\": must be at most 8 characters"},
+		{[]string{"--password", "abc"}, "--password: invalid value \"abc\": must be at least 4 characters"},
+		{[]string{"--level", "info", "trace"}, "--level: invalid value \"trace\": must be one of debug, info"},
+	}
+
+	for _, test := range tests {
+		err = nil
+		p.Parse(test.args...)
+
+		if len(test.message) == 0 {
+			if err != nil {
+				t.Errorf("%v: An unexpected error occurred: %v", test.args, err)
+			}
+			continue
+		}
+		if !errors.Is(err, ErrValidation) {
+			t.Errorf("%v: Expected a validation error, but received: %v", test.args, err)
+			continue
+		}
+		if err.Error() != test.message {
+			t.Errorf("%v: Expected the message \"%s\", but received \"%s\"", test.args, test.message, err.Error())
+		}
+		if index := err.(ValidationErr).Index(); index != len(test.args)-1 {
+			t.Errorf("%v: Expected the error at index %d, but received %d", test.args, len(test.args)-1, index)
+		}
+	}
+
+	p.Parse("--port", "x")
+	if !errors.Is(err, ErrInvalidType) {
+		t.Errorf("Expected the type to be validated first, but received: %v", err)
+	}
+}
+
+// TestValidationErr_Localize tests to ensure the messages of the provided
+// validators are translated using the parser's catalog.
+func TestValidationErr_Localize(t *testing.T) {
+	var err error
+	p := NewParser("program", func(p *Parser, ns *Namespace, args []string, e error) {
+		err = e
+	}).Locale(German)
+	p.AddOption(NewOption("password", "password", "Password").Nargs("1").Action(Store).Validate(MinLen(4)))
+
+	p.Parse("--password", "abc")
+	expected := "--password: ungültiger Wert \"abc\": muss mindestens 4 Zeichen lang sein"
+	if message := p.localize(err); message != expected {
+		t.Errorf("Expected the message \"%s\", but received \"%s\"", expected, message)
+	}
+}
@@ -0,0 +1,11 @@
+//go:build !(linux || darwin || dragonfly || freebsd || netbsd || openbsd)
+
+package argparse
+
+import "os"
+
+// terminalWidth is unsupported on the current platform, and will always
+// return false.
+func terminalWidth(f *os.File) (int, bool) {
+	return 0, false
+}